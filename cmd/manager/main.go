@@ -23,11 +23,19 @@ import (
 
 	"github.com/fbsb/pingdom-operator/pkg/apis"
 	"github.com/fbsb/pingdom-operator/pkg/controller"
+	"github.com/fbsb/pingdom-operator/pkg/metrics"
+	"github.com/fbsb/pingdom-operator/pkg/options"
 	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/maintenance"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/probe"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/report"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
 	"github.com/russellcardullo/go-pingdom/pingdom"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 )
@@ -44,6 +52,12 @@ func main() {
 	flag.StringVar(&pingdomUsername, "pingdom-username", "", "The pingdom username.")
 	flag.StringVar(&pingdomPassword, "pingdom-password", "", "The pingdom password.")
 	flag.StringVar(&pingdomApiKey, "pingdom-api-key", "", "The pingdom API key.")
+	flag.StringVar(&options.ClusterName, "cluster-name", "", "The name of the cluster, used to tag managed pingdom checks.")
+	flag.DurationVar(&options.ResyncPeriod, "resync-period", options.DefaultResyncPeriod, "The interval in which all checks are synced with pingdom, which also refreshes their live state in the status. Set to 0 to disable.")
+	flag.DurationVar(&options.MetricsPollInterval, "metrics-poll-interval", options.DefaultMetricsPollInterval, "The interval in which check results are polled from pingdom and exported as metrics. Set to 0 to disable.")
+	flag.IntVar(&options.RateLimit, "pingdom-rate-limit", options.DefaultRateLimit, "The number of requests per minute to the pingdom checks API shared by the controllers and the polling of check results. Set to 0 to disable.")
+	flag.BoolVar(&options.IngressChecks, "ingress-checks", false, "Generate HttpChecks for Ingresses annotated with pingdom.fbsb.io/check=true. Only Ingresses of the extensions/v1beta1 API are watched.")
+	flag.BoolVar(&options.ServiceChecks, "service-checks", false, "Generate HttpChecks and TCPChecks for LoadBalancer Services annotated with pingdom.fbsb.io/check=true.")
 
 	flag.Parse()
 
@@ -56,18 +70,51 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The credentials are never exposed in the cluster, so they keep the hashes of applied configurations from being reversed
+	options.ConfigHashKey = []byte(pingdomConfig.Password + pingdomConfig.APIKey)
+
 	pingdomClient, err := pingdom.NewClientWithConfig(pingdomConfig)
 	if err != nil {
 		log.Error(err, "could not create pingdom client")
 		os.Exit(1)
 	}
 
-	err = httpcheck.InitService(pingdomClient)
+	err = httpcheck.InitService(pingdomClient, options.RateLimit)
 	if err != nil {
 		log.Error(err, "could not initialize httpcheck service")
 		os.Exit(1)
 	}
 
+	err = team.InitService(pingdomClient)
+	if err != nil {
+		log.Error(err, "could not initialize team service")
+		os.Exit(1)
+	}
+
+	err = user.InitService(pingdomClient)
+	if err != nil {
+		log.Error(err, "could not initialize user service")
+		os.Exit(1)
+	}
+
+	err = probe.InitService(pingdomClient)
+	if err != nil {
+		log.Error(err, "could not initialize probe service")
+		os.Exit(1)
+	}
+
+	err = maintenance.InitService(pingdomClient)
+	if err != nil {
+		log.Error(err, "could not initialize maintenance service")
+		os.Exit(1)
+	}
+
+	err = report.InitService(pingdomClient)
+	if err != nil {
+		log.Error(err, "could not initialize public report service")
+		os.Exit(1)
+	}
+
 	// Get a config to talk to the apiserver
 	log.Info("setting up client for manager")
 	cfg, err := config.GetConfig()
@@ -100,6 +147,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup the exporter for check results
+	log.Info("setting up metrics collector")
+	checkService, err := httpcheck.ServiceInstance()
+	if err != nil {
+		log.Error(err, "could not get httpcheck service")
+		os.Exit(1)
+	}
+
+	collector := metrics.NewCollector(mgr.GetClient(), checkService, options.MetricsPollInterval)
+	if err := mgr.Add(collector); err != nil {
+		log.Error(err, "unable to register metrics collector to the manager")
+		os.Exit(1)
+	}
+
+	// Count the managed checks by their conditions whenever the metrics are scraped
+	crmetrics.Registry.MustRegister(metrics.NewManagedChecks(mgr.GetClient()))
+
 	// Start the Cmd
 	log.Info("Starting the Cmd.")
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
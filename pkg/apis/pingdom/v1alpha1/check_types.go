@@ -0,0 +1,58 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingdomCheckStatus is the status shared by all kinds of checks synced with pingdom
+type PingdomCheckStatus struct {
+	PingdomID     int           `json:"pingdomId,omitempty"`
+	PingdomStatus PingdomStatus `json:"pingdomStatus,omitempty"`
+	Error         string        `json:"error,omitempty"`
+
+	// ObservedGeneration is the generation of the check last synced to pingdom.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the check.
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// CheckStatus is the state of the check reported by pingdom, e.g. up, down, paused or unconfirmed_down.
+	// It and the other live fields below are refreshed on every sync, so they stop updating if neither
+	// the check changes nor periodic resyncs are enabled.
+	CheckStatus string `json:"checkStatus,omitempty"`
+
+	// LastTestTime is the time of the last test run reported by pingdom.
+	LastTestTime *metav1.Time `json:"lastTestTime,omitempty"`
+
+	// LastErrorTime is the time of the last failed test run reported by pingdom.
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+
+	// LastResponseTime is the response time of the last test run in milliseconds.
+	LastResponseTime int64 `json:"lastResponseTime,omitempty"`
+
+	// DriftedFields are the fields last found modified outside of the operator and reverted to the spec.
+	DriftedFields []string `json:"driftedFields,omitempty"`
+
+	// LastDriftTime is the time the check was last found modified outside of the operator.
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// ConfigHash identifies the configuration last applied to pingdom. It distinguishes changes of the spec
+	// or of referenced objects from modifications of the check outside of the operator.
+	ConfigHash string `json:"configHash,omitempty"`
+}
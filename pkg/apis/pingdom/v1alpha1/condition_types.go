@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConditionType string
+
+const (
+	// ConditionReady is true if the pingdom object exists and reflects the current spec
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionSynced is true if the last sync with pingdom succeeded
+	ConditionSynced ConditionType = "Synced"
+
+	// ConditionDegraded is true if the pingdom object exists but the last sync failed,
+	// so pingdom still uses an outdated configuration
+	ConditionDegraded ConditionType = "Degraded"
+)
+
+// Condition describes the state of an object at a certain point
+type Condition struct {
+	Type   ConditionType          `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason is a machine readable explanation for the last transition of the condition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable explanation for the last transition of the condition.
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the time the status of the condition last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SetCondition adds or replaces the condition of the same type.
+// The last transition time is only updated if the status of the condition changes.
+func SetCondition(conditions []Condition, condition Condition) []Condition {
+	for i := range conditions {
+		if conditions[i].Type != condition.Type {
+			continue
+		}
+
+		if conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = conditions[i].LastTransitionTime
+		} else if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+
+		conditions[i] = condition
+		return conditions
+	}
+
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	return append(conditions, condition)
+}
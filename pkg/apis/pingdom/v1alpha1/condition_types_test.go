@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	ready := func(status corev1.ConditionStatus, reason string) Condition {
+		return Condition{Type: ConditionReady, Status: status, Reason: reason, LastTransitionTime: earlier}
+	}
+	synced := Condition{Type: ConditionSynced, Status: corev1.ConditionTrue, LastTransitionTime: earlier}
+
+	tests := []struct {
+		name       string
+		conditions []Condition
+		condition  Condition
+		expected   []Condition
+		transition bool
+	}{
+		{
+			"added",
+			nil,
+			Condition{Type: ConditionReady, Status: corev1.ConditionTrue, Reason: "Synced"},
+			[]Condition{{Type: ConditionReady, Status: corev1.ConditionTrue, Reason: "Synced"}},
+			true,
+		},
+		{
+			"status unchanged",
+			[]Condition{synced, ready(corev1.ConditionFalse, "InvalidSpec")},
+			Condition{Type: ConditionReady, Status: corev1.ConditionFalse, Reason: "RecipientNotFound"},
+			[]Condition{synced, ready(corev1.ConditionFalse, "RecipientNotFound")},
+			false,
+		},
+		{
+			"status changed",
+			[]Condition{synced, ready(corev1.ConditionFalse, "InvalidSpec")},
+			Condition{Type: ConditionReady, Status: corev1.ConditionTrue, Reason: "Synced"},
+			[]Condition{synced, {Type: ConditionReady, Status: corev1.ConditionTrue, Reason: "Synced"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := SetCondition(tt.conditions, tt.condition)
+			assert.Len(t, conditions, len(tt.expected))
+
+			for i, c := range conditions {
+				if tt.transition && c.Type == tt.condition.Type {
+					assert.False(t, c.LastTransitionTime.IsZero())
+					assert.NotEqual(t, earlier, c.LastTransitionTime)
+					c.LastTransitionTime = metav1.Time{}
+				}
+				assert.Equal(t, tt.expected[i], c)
+			}
+		})
+	}
+}
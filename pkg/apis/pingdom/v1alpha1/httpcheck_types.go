@@ -20,6 +20,29 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ResyncPeriodAnnotation overrides the resync period of the operator for a single object, e.g. "5m"
+	ResyncPeriodAnnotation = "pingdom.fbsb.io/resync-period"
+
+	// CheckAnnotation enables the generation of checks for an Ingress or a Service if set to "true"
+	CheckAnnotation = "pingdom.fbsb.io/check"
+
+	// CheckPathAnnotation sets the path requested by the generated http checks, e.g. "/healthz".
+	// For an Ingress it overrides the paths of its rules.
+	CheckPathAnnotation = "pingdom.fbsb.io/check-path"
+
+	// CheckShouldContainAnnotation sets the string the response body of the generated checks must contain
+	CheckShouldContainAnnotation = "pingdom.fbsb.io/check-should-contain"
+
+	// CheckResolutionAnnotation sets the interval in minutes between two test runs of the generated checks, e.g. "1"
+	CheckResolutionAnnotation = "pingdom.fbsb.io/check-resolution"
+
+	// CheckPortsAnnotation lists the ports of a Service to generate checks for, separated by commas.
+	// A port is selected by name or number and may be followed by the protocol to check, e.g. "443/https,5432".
+	// The protocol is one of http, https or tcp and defaults to tcp.
+	CheckPortsAnnotation = "pingdom.fbsb.io/check-ports"
+)
+
 type PingdomStatus string
 
 var (
@@ -31,13 +54,131 @@ var (
 type HttpCheckSpec struct {
 	Name string `json:"name"`
 	URL  string `json:"url"`
+
+	// AdoptPingdomID is the id of an existing pingdom check to take over instead of creating a new one.
+	// It is only used as long as no pingdom check is recorded in the status.
+	AdoptPingdomID int `json:"adoptPingdomId,omitempty"`
+
+	// Resolution is the interval in minutes between two test runs. Defaults to 5.
+	// +kubebuilder:validation:Enum=1,5,15,30,60
+	Resolution int `json:"resolution,omitempty"`
+
+	// Paused stops the check from running without deleting it.
+	Paused bool `json:"paused,omitempty"`
+
+	// PublicReport includes the check in the public status page of the pingdom account.
+	PublicReport bool `json:"publicReport,omitempty"`
+
+	// ResponseTimeThreshold is the response time in milliseconds above which the check is considered down.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30000
+	ResponseTimeThreshold int `json:"responseTimeThreshold,omitempty"`
+
+	// ProbeFilters restricts the pingdom probes running the check.
+	ProbeFilters *ProbeFilters `json:"probeFilters,omitempty"`
+
+	// Notifications configures when alerts are sent for the check.
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// Users are the pingdom users receiving alerts for the check.
+	Users []PingdomReference `json:"users,omitempty"`
+
+	// Teams are the pingdom teams receiving alerts for the check.
+	Teams []PingdomReference `json:"teams,omitempty"`
+
+	// IntegrationIDs are the ids of the pingdom integrations receiving alerts for the check.
+	IntegrationIDs []int `json:"integrationIds,omitempty"`
+
+	// Tags are added to the check in pingdom in addition to the tags managed by the operator.
+	// They are lower cased and characters not allowed by pingdom are replaced with an underscore.
+	Tags []string `json:"tags,omitempty"`
+
+	// ShouldContain is a string the response body must contain for the check to succeed.
+	// It cannot be combined with ShouldNotContain.
+	ShouldContain string `json:"shouldContain,omitempty"`
+
+	// ShouldNotContain is a string the response body must not contain for the check to succeed.
+	// It cannot be combined with ShouldContain.
+	ShouldNotContain string `json:"shouldNotContain,omitempty"`
+
+	// RequestHeaders are additional headers sent with every request of the check.
+	// Pingdom keeps headers removed from the spec, they have to be removed in pingdom as well.
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+
+	// RequestHeadersFrom are additional headers whose values are read from secrets.
+	// They take precedence over RequestHeaders with the same name.
+	RequestHeadersFrom []SecretRequestHeader `json:"requestHeadersFrom,omitempty"`
+
+	// PostData is sent as the request body. Setting it turns the request into a POST.
+	PostData string `json:"postData,omitempty"`
+
+	// BasicAuthSecretRef references a secret holding the credentials used for basic authentication.
+	// The URL must not contain credentials if it is set.
+	BasicAuthSecretRef *BasicAuthSecretReference `json:"basicAuthSecretRef,omitempty"`
+}
+
+// BasicAuthSecretReference selects the username and password of a secret in the namespace of the HttpCheck
+type BasicAuthSecretReference struct {
+	Name string `json:"name"`
+
+	// UsernameKey is the key of the username in the secret. Defaults to "username".
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the password in the secret. Defaults to "password".
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// ProbeFilters restricts the pingdom probes running a check
+type ProbeFilters struct {
+	// Region limits the check to probes of the given region, e.g. NA, EU, APAC or LATAM.
+	Region string `json:"region,omitempty"`
+}
+
+// Notifications defines the alerting policy of a check
+type Notifications struct {
+	// WhenDown is the number of consecutive failed test runs before an alert is sent.
+	// If not set, pingdom uses 2 for new checks and keeps the setting of existing checks.
+	// +kubebuilder:validation:Minimum=1
+	WhenDown int `json:"whenDown,omitempty"`
+
+	// AgainEvery is the number of test runs after which an alert is repeated while the check is down.
+	// Defaults to 0, which disables repeated alerts.
+	// +kubebuilder:validation:Minimum=0
+	AgainEvery int `json:"againEvery,omitempty"`
+
+	// WhenBackUp sends a notification when the check is up again. Defaults to false.
+	WhenBackUp *bool `json:"whenBackUp,omitempty"`
+}
+
+// PingdomReference references a pingdom object by its id or, if the id is not set, by its name
+type PingdomReference struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// SecretKeyReference selects a key of a secret in the namespace of the referencing object
+type SecretKeyReference struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// SecretRequestHeader is a request header with its value stored in a secret
+type SecretRequestHeader struct {
+	Name         string             `json:"name"`
+	SecretKeyRef SecretKeyReference `json:"secretKeyRef"`
 }
 
 // HttpCheckStatus defines the observed state of HttpCheck
 type HttpCheckStatus struct {
-	PingdomID     int           `json:"pingdomId,omitempty"`
-	PingdomStatus PingdomStatus `json:"pingdomStatus,omitempty"`
-	Error         string        `json:"error,omitempty"`
+	PingdomCheckStatus `json:",inline"`
+
+	// PublicReport is true if the check is included in the public status page of the pingdom account.
+	// The public report is only read when the spec or the pingdom id of the check changes, e.g. after a check
+	// was adopted, so other changes outside of the operator are not reverted.
+	PublicReport bool `json:"publicReport,omitempty"`
+
+	// PublicReportCheckID is the pingdom id of the check PublicReport was last read or set for.
+	PublicReportCheckID int `json:"publicReportCheckId,omitempty"`
 }
 
 // +genclient
@@ -46,6 +187,12 @@ type HttpCheckStatus struct {
 // HttpCheck is the Schema for the httpchecks API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pingdom ID",type="integer",JSONPath=".status.pingdomId"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.checkStatus"
+// +kubebuilder:printcolumn:name="Response Time",type="integer",JSONPath=".status.lastResponseTime",description="Response time of the last test run in milliseconds",priority=1
+// +kubebuilder:printcolumn:name="Last Test",type="date",JSONPath=".status.lastTestTime",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type HttpCheck struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
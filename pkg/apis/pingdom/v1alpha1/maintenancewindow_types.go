@@ -0,0 +1,103 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow
+type MaintenanceWindowSpec struct {
+	// Description of the window in pingdom. Defaults to the namespace and name of the MaintenanceWindow.
+	Description string `json:"description,omitempty"`
+
+	// Start is the time the maintenance starts.
+	Start metav1.Time `json:"start"`
+
+	// End is the time the maintenance ends. It must be after the start.
+	End metav1.Time `json:"end"`
+
+	// Recurrence repeats the window. Without recurrence the window only takes place once.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// CheckSelector selects the HttpChecks in the namespace of the MaintenanceWindow which are paused during the window.
+	CheckSelector metav1.LabelSelector `json:"checkSelector"`
+}
+
+// Recurrence configures how a maintenance window is repeated
+type Recurrence struct {
+	// Type is the unit of the interval between two windows.
+	// +kubebuilder:validation:Enum=none,day,week,month
+	Type string `json:"type"`
+
+	// RepeatEvery is the number of days, weeks or months between two windows. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	RepeatEvery int `json:"repeatEvery,omitempty"`
+
+	// Until is the time after which the window is no longer repeated. If it is not set, the end is left to pingdom:
+	// new windows get the default end of pingdom and windows which had an end keep it, since pingdom cannot remove it.
+	// Recreate the MaintenanceWindow to remove the end of an existing window.
+	Until *metav1.Time `json:"until,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	PingdomID     int           `json:"pingdomId,omitempty"`
+	PingdomStatus PingdomStatus `json:"pingdomStatus,omitempty"`
+	Error         string        `json:"error,omitempty"`
+
+	// ObservedGeneration is the generation of the MaintenanceWindow last synced to pingdom.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the MaintenanceWindow.
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// CheckIDs are the ids of the pingdom checks selected by the check selector.
+	CheckIDs []int `json:"checkIds,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MaintenanceWindow is the Schema for the maintenancewindows API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pingdom ID",type="integer",JSONPath=".status.pingdomId"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="Start",type="date",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="End",type="date",JSONPath=".spec.end"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceWindowSpec   `json:"spec,omitempty"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MaintenanceWindowList contains a list of MaintenanceWindow
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
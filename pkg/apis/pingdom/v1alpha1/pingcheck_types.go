@@ -0,0 +1,98 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingCheckSpec defines the desired state of PingCheck
+type PingCheckSpec struct {
+	Name string `json:"name"`
+
+	// Host is the hostname or ip address to ping.
+	Host string `json:"host"`
+
+	// Resolution is the interval in minutes between two test runs. Defaults to 5.
+	// +kubebuilder:validation:Enum=1,5,15,30,60
+	Resolution int `json:"resolution,omitempty"`
+
+	// Paused stops the check from running without deleting it.
+	Paused bool `json:"paused,omitempty"`
+
+	// ResponseTimeThreshold is the response time in milliseconds above which the check is considered down.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30000
+	ResponseTimeThreshold int `json:"responseTimeThreshold,omitempty"`
+
+	// ProbeFilters restricts the pingdom probes running the check.
+	ProbeFilters *ProbeFilters `json:"probeFilters,omitempty"`
+
+	// Notifications configures when alerts are sent for the check.
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// Users are the pingdom users receiving alerts for the check.
+	Users []PingdomReference `json:"users,omitempty"`
+
+	// Teams are the pingdom teams receiving alerts for the check.
+	Teams []PingdomReference `json:"teams,omitempty"`
+
+	// IntegrationIDs are the ids of the pingdom integrations receiving alerts for the check.
+	IntegrationIDs []int `json:"integrationIds,omitempty"`
+
+	// Tags are added to the check in pingdom in addition to the tags managed by the operator.
+	// They are lower cased and characters not allowed by pingdom are replaced with an underscore.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// PingCheckStatus defines the observed state of PingCheck
+type PingCheckStatus struct {
+	PingdomCheckStatus `json:",inline"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingCheck is the Schema for the pingchecks API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pingdom ID",type="integer",JSONPath=".status.pingdomId"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.checkStatus"
+// +kubebuilder:printcolumn:name="Response Time",type="integer",JSONPath=".status.lastResponseTime",description="Response time of the last test run in milliseconds",priority=1
+// +kubebuilder:printcolumn:name="Last Test",type="date",JSONPath=".status.lastTestTime",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PingCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingCheckSpec   `json:"spec,omitempty"`
+	Status PingCheckStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingCheckList contains a list of PingCheck
+type PingCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingCheck{}, &PingCheckList{})
+}
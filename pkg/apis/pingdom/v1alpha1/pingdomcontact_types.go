@@ -0,0 +1,103 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingdomContactSpec defines the desired state of PingdomContact
+type PingdomContactSpec struct {
+	// Name is the name of the pingdom user. An existing pingdom user with the name is adopted instead of creating a new one.
+	Name string `json:"name"`
+
+	// Emails are the email addresses the user receives alerts at.
+	Emails []EmailTarget `json:"emails,omitempty"`
+
+	// SMS are the phone numbers the user receives alerts at by text message.
+	SMS []SMSTarget `json:"sms,omitempty"`
+}
+
+// EmailTarget is an email address receiving alerts
+type EmailTarget struct {
+	Address string `json:"address"`
+
+	// Severity of the alerts sent to the address. Defaults to high.
+	// +kubebuilder:validation:Enum=high,low
+	Severity string `json:"severity,omitempty"`
+}
+
+// SMSTarget is a phone number receiving alerts by text message
+type SMSTarget struct {
+	// CountryCode is the country calling code of the phone number, e.g. 49.
+	CountryCode string `json:"countryCode"`
+
+	// NumberSecretRef selects the key of a secret containing the phone number without the country code.
+	NumberSecretRef SecretKeyReference `json:"numberSecretRef"`
+
+	// Provider sending the text messages. Pingdom chooses the provider if not set.
+	// +kubebuilder:validation:Enum=nexmo,bulksms,esendex,cellsynt
+	Provider string `json:"provider,omitempty"`
+
+	// Severity of the alerts sent to the phone number. Defaults to high.
+	// +kubebuilder:validation:Enum=high,low
+	Severity string `json:"severity,omitempty"`
+}
+
+// PingdomContactStatus defines the observed state of PingdomContact
+type PingdomContactStatus struct {
+	PingdomID     int           `json:"pingdomId,omitempty"`
+	PingdomStatus PingdomStatus `json:"pingdomStatus,omitempty"`
+	Error         string        `json:"error,omitempty"`
+
+	// ObservedGeneration is the generation of the PingdomContact last synced to pingdom.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the PingdomContact.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingdomContact is the Schema for the pingdomcontacts API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Pingdom ID",type="integer",JSONPath=".status.pingdomId"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PingdomContact struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomContactSpec   `json:"spec,omitempty"`
+	Status PingdomContactStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingdomContactList contains a list of PingdomContact
+type PingdomContactList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomContact `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingdomContact{}, &PingdomContactList{})
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingdomTeamSpec defines the desired state of PingdomTeam
+type PingdomTeamSpec struct {
+	Name string `json:"name"`
+
+	// Members are the pingdom users belonging to the team.
+	Members []PingdomReference `json:"members,omitempty"`
+}
+
+// PingdomTeamStatus defines the observed state of PingdomTeam
+type PingdomTeamStatus struct {
+	PingdomID     int           `json:"pingdomId,omitempty"`
+	PingdomStatus PingdomStatus `json:"pingdomStatus,omitempty"`
+	Error         string        `json:"error,omitempty"`
+
+	// ObservedGeneration is the generation of the PingdomTeam last synced to pingdom.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the PingdomTeam.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingdomTeam is the Schema for the pingdomteams API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Pingdom ID",type="integer",JSONPath=".status.pingdomId"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PingdomTeam struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomTeamSpec   `json:"spec,omitempty"`
+	Status PingdomTeamStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PingdomTeamList contains a list of PingdomTeam
+type PingdomTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomTeam `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingdomTeam{}, &PingdomTeamList{})
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/probe"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+const (
+	// Reasons of the status conditions of checks
+	ReasonInvalidSpec        = "InvalidSpec"
+	ReasonRecipientNotFound  = "RecipientNotFound"
+	ReasonInvalidProbeRegion = "InvalidProbeRegion"
+
+	// Reasons of the events of checks
+	EventCreated      = "Created"
+	EventUpdated      = "Updated"
+	EventDeleted      = "Deleted"
+	EventAdopted      = "Adopted"
+	EventDrifted      = "Drifted"
+	EventDeleteFailed = "DeleteFailed"
+)
+
+// Object is a kubernetes resource synced with pingdom
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// CheckKind adapts the check resources of one kind to the CheckReconciler
+type CheckKind interface {
+	// New returns an empty resource of the kind
+	New() Object
+	// CheckStatus returns the status of the check shared by all kinds. It is not named Status to keep
+	// client.Client.Status accessible on kinds embedding the CheckReconciler.
+	CheckStatus(check Object) *pingdomv1alpha1.PingdomCheckStatus
+	// Desired returns the pingdom check described by the spec of the check
+	Desired(check Object) (pingdom.Check, error)
+	// Diff returns the fields of the live pingdom check which differ from the desired check returned by Desired
+	Diff(desired pingdom.Check, live *pingdom.CheckResponse) []string
+}
+
+// CheckHooks is implemented by kinds which manage more than the pingdom check itself.
+// Errors returned by the hooks are handled like the errors of Desired.
+type CheckHooks interface {
+	// Adopt returns the id of an existing pingdom check to take over for a check without a pingdom id, or 0 if there is none
+	Adopt(check Object) (int, error)
+	// Synced is called after the pingdom check was created or updated successfully
+	Synced(check Object, created bool) error
+	// Deleting is called before the pingdom check is deleted
+	Deleting(check Object) error
+}
+
+// SpecError is returned by a CheckKind for a spec which cannot be synced until it is changed
+type SpecError struct {
+	Reason string
+	Err    error
+}
+
+func (e *SpecError) Error() string {
+	return e.Err.Error()
+}
+
+// CheckReconciler reconciles the check resources of one kind with pingdom. It creates, updates and deletes the
+// pingdom check and records the result in the status, conditions and events of the check.
+type CheckReconciler struct {
+	client.Client
+	Service  httpcheck.Service
+	Recorder record.EventRecorder
+	Log      logr.Logger
+
+	kind CheckKind
+}
+
+var _ reconcile.Reconciler = &CheckReconciler{}
+
+// NewCheckReconciler returns a reconciler of the checks of kind named after the controller
+func NewCheckReconciler(mgr manager.Manager, name string, kind CheckKind, service httpcheck.Service) *CheckReconciler {
+	return &CheckReconciler{
+		Client:   mgr.GetClient(),
+		Service:  service,
+		Recorder: mgr.GetRecorder(name + "-controller"),
+		Log:      log.Log.WithName(name + "-reconciler"),
+		kind:     kind,
+	}
+}
+
+// Reconcile reads that state of the cluster for a check and makes changes based on the state read
+// and what is in its spec
+func (r *CheckReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.Log.Info("New reconcile request", "request", request)
+
+	// Fetch the check instance
+	check := r.kind.New()
+	err := r.Get(context.TODO(), request.NamespacedName, check)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !check.GetDeletionTimestamp().IsZero() {
+		// The resource is going to be deleted but we need to do some cleanup first
+
+		err := r.delete(check)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		RemoveFinalizer(check)
+		err = r.Update(context.TODO(), check)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if !HasFinalizer(check) {
+		// The resource is new so we need to make sure we add our finalizer first
+
+		AddFinalizer(check)
+		err := r.Update(context.TODO(), check)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		// The update will trigger the reconciliation again so we might as well just return here
+		return reconcile.Result{}, nil
+	}
+
+	err = r.createOrUpdate(check)
+	return Result(err, ResyncPeriod(check, r.Log))
+}
+
+func (r *CheckReconciler) delete(check Object) error {
+	status := r.kind.CheckStatus(check)
+	if status.PingdomID == 0 {
+		return nil
+	}
+
+	if hooks, ok := r.kind.(CheckHooks); ok {
+		err := hooks.Deleting(check)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := r.Service.Delete(status.PingdomID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// just return if pingdom id does not exist
+			return nil
+		}
+
+		r.Recorder.Eventf(check, corev1.EventTypeWarning, EventDeleteFailed,
+			"Could not delete pingdom check %d: %s", status.PingdomID, err)
+		return err
+	}
+
+	r.Recorder.Eventf(check, corev1.EventTypeNormal, EventDeleted, "Deleted pingdom check %d", status.PingdomID)
+
+	return nil
+}
+
+func (r *CheckReconciler) createOrUpdate(check Object) error {
+	status := r.kind.CheckStatus(check)
+
+	desired, err := r.kind.Desired(check)
+	if err != nil {
+		return r.failure(check, err)
+	}
+
+	hooks, _ := r.kind.(CheckHooks)
+
+	if status.PingdomID == 0 && hooks != nil {
+		id, err := hooks.Adopt(check)
+		if err != nil {
+			return r.failure(check, err)
+		}
+
+		if id != 0 {
+			status.PingdomID = id
+			r.Recorder.Eventf(check, corev1.EventTypeNormal, EventAdopted, "Adopted existing pingdom check %d", id)
+		}
+	}
+
+	if status.PingdomID != 0 {
+		err := r.sync(check, desired)
+
+		if err == nil {
+			return r.synced(check, false)
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return r.PingdomFailure(check, err)
+		}
+
+		// The check was deleted in pingdom so we need to create it again
+		r.Log.Info("Pingdom check not found, creating a new one", "namespace", check.GetNamespace(), "name", check.GetName(), "pingdomId", status.PingdomID)
+	}
+
+	resp, err := r.Service.Create(desired)
+	if err != nil {
+		return r.PingdomFailure(check, err)
+	}
+
+	// keep the id of the new check even if the status cannot be updated with the result of the sync
+	status.PingdomID = resp.ID
+	status.ConfigHash = checkutil.ConfigHash(options.ConfigHashKey, desired.PutParams())
+	r.Recorder.Eventf(check, corev1.EventTypeNormal, EventCreated, "Created pingdom check %d", resp.ID)
+
+	return r.synced(check, true)
+}
+
+// synced runs the hook of a successfully synced check and records the success of the sync
+func (r *CheckReconciler) synced(check Object, created bool) error {
+	if hooks, ok := r.kind.(CheckHooks); ok {
+		err := hooks.Synced(check, created)
+		if err != nil {
+			return r.failure(check, err)
+		}
+	}
+
+	return r.statusSuccess(check)
+}
+
+// sync reads the check from pingdom and updates it if it differs from the desired check
+func (r *CheckReconciler) sync(check Object, desired pingdom.Check) error {
+	status := r.kind.CheckStatus(check)
+
+	live, err := r.Service.Read(status.PingdomID)
+	if err != nil {
+		return err
+	}
+
+	SetLiveStatus(status, live)
+
+	// Changes of the configuration are applied even if they cannot be compared with the live check, e.g. probe filters
+	hash := checkutil.ConfigHash(options.ConfigHashKey, desired.PutParams())
+	changed := hash != status.ConfigHash
+
+	diff := r.kind.Diff(desired, live)
+	if len(diff) == 0 && !changed {
+		return nil
+	}
+
+	// The configuration did not change since it was last applied, so the check was modified outside of the operator
+	if !changed {
+		now := metav1.Now()
+		status.DriftedFields = diff
+		status.LastDriftTime = &now
+		r.Recorder.Eventf(check, corev1.EventTypeWarning, EventDrifted,
+			"Pingdom check %d was modified outside of the operator, reverting %s", status.PingdomID, strings.Join(diff, ", "))
+	}
+
+	_, err = r.Service.Update(status.PingdomID, desired)
+	if err != nil {
+		return err
+	}
+
+	status.ConfigHash = hash
+
+	if len(diff) == 0 {
+		r.Recorder.Eventf(check, corev1.EventTypeNormal, EventUpdated, "Updated pingdom check %d", status.PingdomID)
+	} else {
+		r.Recorder.Eventf(check, corev1.EventTypeNormal, EventUpdated,
+			"Updated %s of pingdom check %d", strings.Join(diff, ", "), status.PingdomID)
+	}
+
+	return nil
+}
+
+// SetLiveStatus copies the state of the check reported by pingdom into the status
+func SetLiveStatus(status *pingdomv1alpha1.PingdomCheckStatus, live *pingdom.CheckResponse) {
+	status.CheckStatus = live.Status
+	status.LastTestTime = UnixTime(live.LastTestTime)
+	status.LastErrorTime = UnixTime(live.LastErrorTime)
+	status.LastResponseTime = live.LastResponseTime
+}
+
+// failure records errors caused by the spec or returned by the pingdom api in the status.
+// All other errors are returned unchanged to be retried with backoff.
+func (r *CheckReconciler) failure(check Object, err error) error {
+	switch err.(type) {
+	case *pingdom.PingdomError:
+		return r.PingdomFailure(check, err)
+	case *SpecError, *team.NotFoundError, *user.NotFoundError, *probe.InvalidRegionError, *InvalidReferenceError:
+		return r.StatusFailure(check, err)
+	}
+
+	return err
+}
+
+// StatusFailure records the error which caused the sync of the check to fail in its status
+func (r *CheckReconciler) StatusFailure(check Object, err error) error {
+	status := r.kind.CheckStatus(check)
+	message := ErrorMessage(err)
+
+	status.Error = message
+	status.PingdomStatus = pingdomv1alpha1.StatusFail
+	status.ObservedGeneration = check.GetGeneration()
+
+	reason := CheckFailureReason(err)
+	degraded := corev1.ConditionFalse
+	if status.PingdomID != 0 {
+		degraded = corev1.ConditionTrue
+		r.Recorder.Eventf(check, corev1.EventTypeWarning, reason, "Could not sync pingdom check %d: %s", status.PingdomID, message)
+	} else {
+		r.Recorder.Eventf(check, corev1.EventTypeWarning, reason, "Could not create pingdom check: %s", message)
+	}
+	status.Conditions = SetCheckConditions(status.Conditions, status.PingdomID, corev1.ConditionFalse, degraded, reason, message)
+
+	return r.Status().Update(context.TODO(), check)
+}
+
+// PingdomFailure records a failed pingdom api request in the status of the check and decides how the request is retried
+func (r *CheckReconciler) PingdomFailure(check Object, err error) error {
+	return PingdomFailure(err, func(err error) error {
+		return r.StatusFailure(check, err)
+	})
+}
+
+func (r *CheckReconciler) statusSuccess(check Object) error {
+	status := r.kind.CheckStatus(check)
+
+	status.Error = ""
+	status.PingdomStatus = pingdomv1alpha1.StatusSuccess
+	status.ObservedGeneration = check.GetGeneration()
+
+	message := fmt.Sprintf("Pingdom check %d is up to date", status.PingdomID)
+	status.Conditions = SetCheckConditions(status.Conditions, status.PingdomID, corev1.ConditionTrue, corev1.ConditionFalse, ReasonSynced, message)
+
+	return r.Status().Update(context.TODO(), check)
+}
+
+// CheckFailureReason returns the condition reason for the error which caused the sync of a check to fail
+func CheckFailureReason(err error) string {
+	switch e := err.(type) {
+	case *pingdom.PingdomError:
+		return string(apierrors.ReasonForError(err))
+	case *SpecError:
+		return e.Reason
+	case *team.NotFoundError, *user.NotFoundError:
+		return ReasonRecipientNotFound
+	case *probe.InvalidRegionError:
+		return ReasonInvalidProbeRegion
+	}
+
+	return ReasonInvalidSpec
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/probe"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetLiveStatus(t *testing.T) {
+	tested := metav1.NewTime(time.Unix(1556748000, 0))
+	failed := metav1.NewTime(time.Unix(1556744400, 0))
+
+	tests := []struct {
+		name   string
+		status pingdomv1alpha1.PingdomCheckStatus
+		live   pingdom.CheckResponse
+		result pingdomv1alpha1.PingdomCheckStatus
+	}{
+		{
+			"not tested yet",
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1},
+			pingdom.CheckResponse{ID: 1, Status: "unknown"},
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1, CheckStatus: "unknown"},
+		},
+		{
+			"tested",
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1},
+			pingdom.CheckResponse{ID: 1, Status: "up", LastTestTime: 1556748000, LastErrorTime: 1556744400, LastResponseTime: 120},
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1, CheckStatus: "up", LastTestTime: &tested, LastErrorTime: &failed, LastResponseTime: 120},
+		},
+		{
+			"error cleared",
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1, CheckStatus: "down", LastTestTime: &tested, LastErrorTime: &failed},
+			pingdom.CheckResponse{ID: 1, Status: "paused", LastTestTime: 1556748000},
+			pingdomv1alpha1.PingdomCheckStatus{PingdomID: 1, CheckStatus: "paused", LastTestTime: &tested},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			SetLiveStatus(&status, &tt.live)
+			assert.Equal(t, tt.result, status)
+		})
+	}
+}
+
+func TestCheckFailureReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			"pingdom error",
+			&pingdom.PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"},
+			"RateLimited",
+		},
+		{
+			"spec error",
+			&SpecError{Reason: "SecretNotFound", Err: errors.New(`secrets "auth" not found`)},
+			"SecretNotFound",
+		},
+		{
+			"team not found",
+			&team.NotFoundError{Name: "ops"},
+			ReasonRecipientNotFound,
+		},
+		{
+			"user not found",
+			&user.NotFoundError{Name: "alice"},
+			ReasonRecipientNotFound,
+		},
+		{
+			"invalid region",
+			&probe.InvalidRegionError{Region: "moon"},
+			ReasonInvalidProbeRegion,
+		},
+		{
+			"invalid reference",
+			&InvalidReferenceError{Field: "teams"},
+			ReasonInvalidSpec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reason, CheckFailureReason(tt.err))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains the reconcile helpers shared by the controllers of pingdom objects
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Finalizer is added to every resource owning an object in pingdom, so the object is deleted with the resource
+	Finalizer = "finalizer.pingdom.fbsb.io"
+
+	// RateLimitDelay is the time to wait before retrying a request rejected by the pingdom rate limit
+	RateLimitDelay = time.Minute
+)
+
+// RequeueAfterError requests the reconciliation to be retried after a delay instead of with backoff
+type RequeueAfterError struct {
+	After time.Duration
+}
+
+func (e *RequeueAfterError) Error() string {
+	return fmt.Sprintf("requeue after %s", e.After)
+}
+
+// Result returns the result of a reconciliation which failed with err or else should be repeated after resync
+func Result(err error, resync time.Duration) (reconcile.Result, error) {
+	if err != nil {
+		if rErr, ok := err.(*RequeueAfterError); ok {
+			return reconcile.Result{RequeueAfter: rErr.After}, nil
+		}
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	return reconcile.Result{RequeueAfter: resync}, nil
+}
+
+// PingdomFailure records a failed pingdom api request with statusFailure and decides how the request is retried.
+// Invalid requests and authorization failures are not retried before the next change or resync,
+// rate limited requests are retried after a delay and all other errors are retried with backoff.
+// Errors not returned by the pingdom api are returned unchanged without being recorded.
+func PingdomFailure(err error, statusFailure func(error) error) error {
+	if _, ok := err.(*pingdom.PingdomError); !ok {
+		return err
+	}
+
+	sErr := statusFailure(err)
+	if sErr != nil {
+		return sErr
+	}
+
+	switch apierrors.ReasonForError(err) {
+	case apierrors.ReasonInvalid, apierrors.ReasonUnauthorized:
+		return nil
+	case apierrors.ReasonRateLimited:
+		return &RequeueAfterError{After: RateLimitDelay}
+	}
+
+	return err
+}
+
+// ErrorMessage returns the message to record in the status for the error.
+// Errors of the pingdom api are reduced to the message returned by pingdom.
+func ErrorMessage(err error) string {
+	if pErr, ok := err.(*pingdom.PingdomError); ok {
+		return pErr.Message
+	}
+
+	return err.Error()
+}
+
+// UnixTime converts a timestamp reported by pingdom. Zero timestamps are not set.
+func UnixTime(sec int64) *metav1.Time {
+	if sec == 0 {
+		return nil
+	}
+
+	t := metav1.Unix(sec, 0)
+	return &t
+}
+
+// Helper functions to manage resource finalizers
+
+// HasFinalizer returns true if the finalizer of the operator is set on the object
+func HasFinalizer(obj metav1.Object) bool {
+	for _, fin := range obj.GetFinalizers() {
+		if fin == Finalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddFinalizer adds the finalizer of the operator to the object
+func AddFinalizer(obj metav1.Object) {
+	obj.SetFinalizers(append(obj.GetFinalizers(), Finalizer))
+}
+
+// RemoveFinalizer removes the finalizer of the operator from the object
+func RemoveFinalizer(obj metav1.Object) {
+	var output []string
+
+	for _, f := range obj.GetFinalizers() {
+		if f != Finalizer {
+			output = append(output, f)
+		}
+	}
+
+	obj.SetFinalizers(output)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestResult(t *testing.T) {
+	failed := errors.New("connection refused")
+
+	tests := []struct {
+		name   string
+		err    error
+		resync time.Duration
+		result reconcile.Result
+		rErr   error
+	}{
+		{
+			"success without resync",
+			nil,
+			0,
+			reconcile.Result{},
+			nil,
+		},
+		{
+			"success with resync",
+			nil,
+			time.Minute,
+			reconcile.Result{RequeueAfter: time.Minute},
+			nil,
+		},
+		{
+			"failure",
+			failed,
+			time.Minute,
+			reconcile.Result{Requeue: true},
+			failed,
+		},
+		{
+			"requeue after delay",
+			&RequeueAfterError{After: RateLimitDelay},
+			time.Minute,
+			reconcile.Result{RequeueAfter: RateLimitDelay},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Result(tt.err, tt.resync)
+			assert.Equal(t, tt.result, result)
+			assert.Equal(t, tt.rErr, err)
+		})
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	tests := []struct {
+		name string
+		sec  int64
+		time *metav1.Time
+	}{
+		{
+			"not set",
+			0,
+			nil,
+		},
+		{
+			"timestamp",
+			1556748000,
+			&metav1.Time{Time: time.Unix(1556748000, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.time, UnixTime(tt.sec))
+		})
+	}
+}
+
+func TestPingdomFailure(t *testing.T) {
+	failed := errors.New("connection refused")
+	serverError := &pingdom.PingdomError{StatusCode: 500, StatusDesc: "Internal Server Error"}
+	statusError := errors.New("the object has been modified")
+
+	tests := []struct {
+		name        string
+		err         error
+		statusErr   error
+		recorded    bool
+		returnedErr error
+	}{
+		{
+			"not a pingdom error",
+			failed,
+			nil,
+			false,
+			failed,
+		},
+		{
+			"invalid request",
+			&pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request"},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"unauthorized",
+			&pingdom.PingdomError{StatusCode: 401, StatusDesc: "Unauthorized"},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"rate limited",
+			&pingdom.PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"},
+			nil,
+			true,
+			&RequeueAfterError{After: RateLimitDelay},
+		},
+		{
+			"server error",
+			serverError,
+			nil,
+			true,
+			serverError,
+		},
+		{
+			"status update failed",
+			&pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request"},
+			statusError,
+			true,
+			statusError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorded := false
+			err := PingdomFailure(tt.err, func(err error) error {
+				recorded = true
+				assert.Equal(t, tt.err, err)
+				return tt.statusErr
+			})
+
+			assert.Equal(t, tt.recorded, recorded)
+			assert.Equal(t, tt.returnedErr, err)
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{
+			"error",
+			errors.New("the name should not be empty string"),
+			"the name should not be empty string",
+		},
+		{
+			"pingdom error",
+			&pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Invalid parameter value: resolution"},
+			"Invalid parameter value: resolution",
+		},
+		{
+			"spec error",
+			&SpecError{Reason: ReasonInvalidSpec, Err: errors.New("the url should not be empty string")},
+			"the url should not be empty string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.message, ErrorMessage(tt.err))
+		})
+	}
+}
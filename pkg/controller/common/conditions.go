@@ -0,0 +1,59 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonSynced is the reason of the conditions of a resource successfully synced with pingdom
+const ReasonSynced = "Synced"
+
+// SetConditions sets the ready and synced conditions to the given status
+func SetConditions(conditions []pingdomv1alpha1.Condition, status corev1.ConditionStatus, reason string, message string) []pingdomv1alpha1.Condition {
+	for _, t := range []pingdomv1alpha1.ConditionType{pingdomv1alpha1.ConditionReady, pingdomv1alpha1.ConditionSynced} {
+		conditions = pingdomv1alpha1.SetCondition(conditions, pingdomv1alpha1.Condition{
+			Type:    t,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+
+	return conditions
+}
+
+// SetCheckConditions sets the ready and synced conditions of a check to the given status and the degraded condition
+// to degraded. A check is degraded if it exists in pingdom with the configuration of an earlier sync.
+func SetCheckConditions(conditions []pingdomv1alpha1.Condition, id int, status corev1.ConditionStatus, degraded corev1.ConditionStatus, reason string, message string) []pingdomv1alpha1.Condition {
+	conditions = SetConditions(conditions, status, reason, message)
+
+	degradedReason, degradedMessage := ReasonSynced, message
+	if degraded == corev1.ConditionTrue {
+		degradedReason, degradedMessage = reason, fmt.Sprintf("Pingdom check %d uses an outdated configuration: %s", id, message)
+	}
+
+	return pingdomv1alpha1.SetCondition(conditions, pingdomv1alpha1.Condition{
+		Type:    pingdomv1alpha1.ConditionDegraded,
+		Status:  degraded,
+		Reason:  degradedReason,
+		Message: degradedMessage,
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSetCheckConditions(t *testing.T) {
+	type condition struct {
+		status  corev1.ConditionStatus
+		reason  string
+		message string
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		status   corev1.ConditionStatus
+		degraded corev1.ConditionStatus
+		reason   string
+		message  string
+		expected map[pingdomv1alpha1.ConditionType]condition
+	}{
+		{
+			"synced",
+			1,
+			corev1.ConditionTrue,
+			corev1.ConditionFalse,
+			ReasonSynced,
+			"Pingdom check 1 is up to date",
+			map[pingdomv1alpha1.ConditionType]condition{
+				pingdomv1alpha1.ConditionReady:    {corev1.ConditionTrue, ReasonSynced, "Pingdom check 1 is up to date"},
+				pingdomv1alpha1.ConditionSynced:   {corev1.ConditionTrue, ReasonSynced, "Pingdom check 1 is up to date"},
+				pingdomv1alpha1.ConditionDegraded: {corev1.ConditionFalse, ReasonSynced, "Pingdom check 1 is up to date"},
+			},
+		},
+		{
+			"not created",
+			0,
+			corev1.ConditionFalse,
+			corev1.ConditionFalse,
+			ReasonInvalidSpec,
+			"the name should not be empty string",
+			map[pingdomv1alpha1.ConditionType]condition{
+				pingdomv1alpha1.ConditionReady:    {corev1.ConditionFalse, ReasonInvalidSpec, "the name should not be empty string"},
+				pingdomv1alpha1.ConditionSynced:   {corev1.ConditionFalse, ReasonInvalidSpec, "the name should not be empty string"},
+				pingdomv1alpha1.ConditionDegraded: {corev1.ConditionFalse, ReasonSynced, "the name should not be empty string"},
+			},
+		},
+		{
+			"degraded",
+			1,
+			corev1.ConditionFalse,
+			corev1.ConditionTrue,
+			ReasonRecipientNotFound,
+			`could not find pingdom team "ops"`,
+			map[pingdomv1alpha1.ConditionType]condition{
+				pingdomv1alpha1.ConditionReady:  {corev1.ConditionFalse, ReasonRecipientNotFound, `could not find pingdom team "ops"`},
+				pingdomv1alpha1.ConditionSynced: {corev1.ConditionFalse, ReasonRecipientNotFound, `could not find pingdom team "ops"`},
+				pingdomv1alpha1.ConditionDegraded: {corev1.ConditionTrue, ReasonRecipientNotFound,
+					`Pingdom check 1 uses an outdated configuration: could not find pingdom team "ops"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := SetCheckConditions(nil, tt.id, tt.status, tt.degraded, tt.reason, tt.message)
+			assert.Len(t, conditions, len(tt.expected))
+
+			for _, c := range conditions {
+				assert.Equal(t, tt.expected[c.Type], condition{c.Status, c.Reason, c.Message}, string(c.Type))
+			}
+		})
+	}
+}
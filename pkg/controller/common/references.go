@@ -0,0 +1,70 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+)
+
+// InvalidReferenceError is returned for a reference which sets neither an id nor a name
+type InvalidReferenceError struct {
+	Field string
+}
+
+func (e *InvalidReferenceError) Error() string {
+	return fmt.Sprintf("the references in %s must set either an id or a name", e.Field)
+}
+
+// UserIDs returns the pingdom ids of the users referenced in field, looking up users referenced by name
+func UserIDs(service user.Service, field string, refs []pingdomv1alpha1.PingdomReference) ([]int, error) {
+	ids, names, err := SplitReferences(field, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ResolveIDs(service, ids, names)
+}
+
+// TeamIDs returns the pingdom ids of the teams referenced in field, looking up teams referenced by name
+func TeamIDs(service team.Service, field string, refs []pingdomv1alpha1.PingdomReference) ([]int, error) {
+	ids, names, err := SplitReferences(field, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return team.ResolveIDs(service, ids, names)
+}
+
+// SplitReferences separates references by id from references by name
+func SplitReferences(field string, refs []pingdomv1alpha1.PingdomReference) (ids []int, names []string, err error) {
+	for _, ref := range refs {
+		switch {
+		case ref.ID != 0:
+			ids = append(ids, ref.ID)
+		case ref.Name != "":
+			names = append(names, ref.Name)
+		default:
+			return nil, nil, &InvalidReferenceError{Field: field}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"time"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// resyncJitterFactor spreads the resyncs of many checks so they don't hit the pingdom api at once
+	resyncJitterFactor = 0.1
+)
+
+// ResyncPeriod returns the jittered duration after which the object is synced again.
+// The period can be overridden per object by an annotation. A duration of zero disables the resync.
+func ResyncPeriod(obj metav1.Object, log logr.Logger) time.Duration {
+	period := options.ResyncPeriod
+
+	if value, ok := obj.GetAnnotations()[pingdomv1alpha1.ResyncPeriodAnnotation]; ok {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			log.Info("Ignoring invalid resync period annotation", "namespace", obj.GetNamespace(), "name", obj.GetName(), "value", value)
+		} else {
+			period = d
+		}
+	}
+
+	if period == 0 {
+		return 0
+	}
+
+	return wait.Jitter(period, resyncJitterFactor)
+}
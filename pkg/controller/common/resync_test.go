@@ -0,0 +1,94 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestResyncPeriod(t *testing.T) {
+	defer func(period time.Duration) { options.ResyncPeriod = period }(options.ResyncPeriod)
+
+	tests := []struct {
+		name        string
+		period      time.Duration
+		annotations map[string]string
+		resync      time.Duration
+	}{
+		{
+			"default period",
+			10 * time.Minute,
+			nil,
+			10 * time.Minute,
+		},
+		{
+			"disabled",
+			0,
+			nil,
+			0,
+		},
+		{
+			"annotation",
+			10 * time.Minute,
+			map[string]string{pingdomv1alpha1.ResyncPeriodAnnotation: "1h"},
+			time.Hour,
+		},
+		{
+			"disabled by annotation",
+			10 * time.Minute,
+			map[string]string{pingdomv1alpha1.ResyncPeriodAnnotation: "0s"},
+			0,
+		},
+		{
+			"enabled by annotation",
+			0,
+			map[string]string{pingdomv1alpha1.ResyncPeriodAnnotation: "5m"},
+			5 * time.Minute,
+		},
+		{
+			"invalid annotation",
+			10 * time.Minute,
+			map[string]string{pingdomv1alpha1.ResyncPeriodAnnotation: "often"},
+			10 * time.Minute,
+		},
+		{
+			"negative annotation",
+			10 * time.Minute,
+			map[string]string{pingdomv1alpha1.ResyncPeriodAnnotation: "-5m"},
+			10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options.ResyncPeriod = tt.period
+			obj := &metav1.ObjectMeta{Name: "example", Annotations: tt.annotations}
+
+			resync := ResyncPeriod(obj, log.Log)
+
+			// the period is jittered by up to resyncJitterFactor
+			assert.True(t, resync >= tt.resync, "resync %s shorter than %s", resync, tt.resync)
+			assert.True(t, resync <= time.Duration(float64(tt.resync)*(1+resyncJitterFactor)), "resync %s too long for %s", resync, tt.resync)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SecretKeyError is returned when a referenced secret does not contain the requested key
+type SecretKeyError struct {
+	Name string
+	Key  string
+}
+
+func (e *SecretKeyError) Error() string {
+	return fmt.Sprintf("the secret %q does not contain the key %q", e.Name, e.Key)
+}
+
+// SecretValue returns the value of the key in the secret with the given name
+func SecretValue(c client.Client, namespace string, name string, key string) (string, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", &SecretKeyError{Name: name, Key: key}
+	}
+
+	return string(value), nil
+}
+
+// SecretMapper maps a secret to reconcile requests for all objects in its namespace referencing it
+type SecretMapper struct {
+	client.Client
+	Log logr.Logger
+
+	// NewList returns an empty list of the objects which can reference secrets
+	NewList func() runtime.Object
+	// References returns true if the object references the secret with the given name
+	References func(obj runtime.Object, name string) bool
+}
+
+var _ handler.Mapper = &SecretMapper{}
+
+func (m *SecretMapper) Map(obj handler.MapObject) []reconcile.Request {
+	list := m.NewList()
+	err := m.List(context.TODO(), client.InNamespace(obj.Meta.GetNamespace()), list)
+	if err != nil {
+		m.Log.Error(err, "could not list objects referencing secret", "namespace", obj.Meta.GetNamespace(), "name", obj.Meta.GetName())
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		m.Log.Error(err, "could not extract objects referencing secret", "namespace", obj.Meta.GetNamespace(), "name", obj.Meta.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range items {
+		if !m.References(item, obj.Meta.GetName()) {
+			continue
+		}
+
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generated
+
+import (
+	"strconv"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+)
+
+// resolutions are the intervals in minutes between two test runs supported by pingdom
+var resolutions = map[int]bool{1: true, 5: true, 15: true, 30: true, 60: true}
+
+// Resolution returns the resolution set by annotation or zero for the default resolution.
+// An invalid resolution is reported as event of the owner.
+func Resolution(owner Object, recorder record.EventRecorder) int {
+	value, ok := owner.GetAnnotations()[pingdomv1alpha1.CheckResolutionAnnotation]
+	if !ok {
+		return 0
+	}
+
+	resolution, err := strconv.Atoi(value)
+	if err != nil || !resolutions[resolution] {
+		recorder.Eventf(owner, corev1.EventTypeWarning, EventInvalidAnnotation,
+			"Ignoring invalid check resolution %q, it must be one of 1, 5, 15, 30 or 60", value)
+		return 0
+	}
+
+	return resolution
+}
+
+// CheckName returns the name of a check generated for the owner. The name of the owner is shortened
+// if needed, so the suffix identifying the check within the checks of the owner is always kept.
+func CheckName(ownerName string, suffix string) string {
+	if max := validation.DNS1123SubdomainMaxLength - len(suffix); len(ownerName) > max {
+		ownerName = ownerName[:max]
+	}
+
+	return ownerName + suffix
+}
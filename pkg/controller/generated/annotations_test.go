@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generated
+
+import (
+	"strings"
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestResolution(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		resolution  int
+		events      int
+	}{
+		{
+			"no annotation",
+			nil,
+			0,
+			0,
+		},
+		{
+			"valid resolution",
+			map[string]string{pingdomv1alpha1.CheckResolutionAnnotation: "15"},
+			15,
+			0,
+		},
+		{
+			"unsupported resolution",
+			map[string]string{pingdomv1alpha1.CheckResolutionAnnotation: "10"},
+			0,
+			1,
+		},
+		{
+			"not a number",
+			map[string]string{pingdomv1alpha1.CheckResolutionAnnotation: "often"},
+			0,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			owner := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			assert.Equal(t, tt.resolution, Resolution(owner, recorder))
+			assert.Len(t, recorder.Events, tt.events)
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	assert.Equal(t, "web-80-http", CheckName("web", "-80-http"))
+
+	long := CheckName(strings.Repeat("a", validation.DNS1123SubdomainMaxLength), "-80-http")
+	assert.Len(t, long, validation.DNS1123SubdomainMaxLength)
+	assert.True(t, strings.HasSuffix(long, "-80-http"))
+}
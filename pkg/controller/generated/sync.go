@@ -0,0 +1,202 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generated contains the helpers shared by the controllers generating checks for other kubernetes objects
+package generated
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Reasons of the events
+	EventCreated           = "CheckCreated"
+	EventUpdated           = "CheckUpdated"
+	EventDeleted           = "CheckDeleted"
+	EventInvalidAnnotation = "InvalidAnnotation"
+	EventConflict          = "CheckConflict"
+)
+
+// Object is a kubernetes object checks are generated for, or a generated check
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// check adapts the generated checks of the different kinds to the sync
+type check interface {
+	object() Object
+	kind() string
+	target() string
+	specEqual(other check) bool
+	copySpec(from check)
+}
+
+type httpCheck struct {
+	*pingdomv1alpha1.HttpCheck
+}
+
+func (c httpCheck) object() Object      { return c.HttpCheck }
+func (c httpCheck) kind() string        { return "HttpCheck" }
+func (c httpCheck) target() string      { return c.Spec.URL }
+func (c httpCheck) copySpec(from check) { c.Spec = from.(httpCheck).Spec }
+func (c httpCheck) specEqual(other check) bool {
+	return reflect.DeepEqual(c.Spec, other.(httpCheck).Spec)
+}
+
+type tcpCheck struct {
+	*pingdomv1alpha1.TCPCheck
+}
+
+func (c tcpCheck) object() Object { return c.TCPCheck }
+func (c tcpCheck) kind() string   { return "TCPCheck" }
+func (c tcpCheck) target() string {
+	return net.JoinHostPort(c.Spec.Host, strconv.Itoa(c.Spec.Port))
+}
+func (c tcpCheck) copySpec(from check) { c.Spec = from.(tcpCheck).Spec }
+func (c tcpCheck) specEqual(other check) bool {
+	return reflect.DeepEqual(c.Spec, other.(tcpCheck).Spec)
+}
+
+// Syncer creates, updates and deletes the checks generated for an owner and reports the changes as events of the owner
+type Syncer struct {
+	client.Client
+	Recorder record.EventRecorder
+}
+
+// SyncHttpChecks creates and updates the desired HttpChecks and deletes all other HttpChecks generated for the owner
+func (s *Syncer) SyncHttpChecks(owner Object, desired []*pingdomv1alpha1.HttpCheck) error {
+	list := &pingdomv1alpha1.HttpCheckList{}
+	err := s.List(context.TODO(), client.InNamespace(owner.GetNamespace()), list)
+	if err != nil {
+		return err
+	}
+
+	var existing, want []check
+	for i := range list.Items {
+		existing = append(existing, httpCheck{&list.Items[i]})
+	}
+	for _, c := range desired {
+		want = append(want, httpCheck{c})
+	}
+
+	return s.sync(owner, existing, want)
+}
+
+// SyncTCPChecks creates and updates the desired TCPChecks and deletes all other TCPChecks generated for the owner
+func (s *Syncer) SyncTCPChecks(owner Object, desired []*pingdomv1alpha1.TCPCheck) error {
+	list := &pingdomv1alpha1.TCPCheckList{}
+	err := s.List(context.TODO(), client.InNamespace(owner.GetNamespace()), list)
+	if err != nil {
+		return err
+	}
+
+	var existing, want []check
+	for i := range list.Items {
+		existing = append(existing, tcpCheck{&list.Items[i]})
+	}
+	for _, c := range desired {
+		want = append(want, tcpCheck{c})
+	}
+
+	return s.sync(owner, existing, want)
+}
+
+// sync creates or updates the desired checks and deletes the checks controlled by the owner which are not desired.
+// Desired checks whose name is taken by a check not controlled by the owner are skipped and reported as a
+// conflict, since retrying cannot resolve them until the other check is removed.
+func (s *Syncer) sync(owner Object, existing []check, desired []check) error {
+	generated := map[string]check{}
+	foreign := map[string]bool{}
+	for _, c := range existing {
+		if metav1.IsControlledBy(c.object(), owner) {
+			generated[c.object().GetName()] = c
+		} else {
+			foreign[c.object().GetName()] = true
+		}
+	}
+
+	for _, c := range desired {
+		current, ok := generated[c.object().GetName()]
+		delete(generated, c.object().GetName())
+
+		if !ok {
+			if foreign[c.object().GetName()] {
+				s.conflict(owner, c)
+				continue
+			}
+
+			err := s.Create(context.TODO(), c.object())
+			if errors.IsAlreadyExists(err) {
+				// the check was created after the list was read, e.g. by a user
+				s.conflict(owner, c)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			s.event(owner, EventCreated, "Created", c)
+			continue
+		}
+
+		if current.specEqual(c) {
+			continue
+		}
+
+		current.copySpec(c)
+		err := s.Update(context.TODO(), current.object())
+		if err != nil {
+			return err
+		}
+
+		s.event(owner, EventUpdated, "Updated", c)
+	}
+
+	for _, current := range generated {
+		err := s.Delete(context.TODO(), current.object())
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		s.event(owner, EventDeleted, "Deleted", current)
+	}
+
+	return nil
+}
+
+func (s *Syncer) conflict(owner Object, c check) {
+	s.Recorder.Event(owner, corev1.EventTypeWarning, EventConflict,
+		fmt.Sprintf("Skipped %s %s for %s: a %s with the name exists and is not controlled by %s",
+			c.kind(), c.object().GetName(), c.target(), c.kind(), owner.GetName()))
+}
+
+func (s *Syncer) event(owner Object, reason string, action string, c check) {
+	s.Recorder.Event(owner, corev1.EventTypeNormal, reason,
+		fmt.Sprintf("%s %s %s for %s", action, c.kind(), c.object().GetName(), c.target()))
+}
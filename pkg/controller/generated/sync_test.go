@@ -0,0 +1,148 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generated
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeWriter records the objects written by the Syncer. Creating an object named in exists fails with AlreadyExists.
+type fakeWriter struct {
+	client.Client
+	exists  map[string]bool
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (w *fakeWriter) Create(ctx context.Context, obj runtime.Object) error {
+	name := obj.(metav1.Object).GetName()
+	if w.exists[name] {
+		return errors.NewAlreadyExists(schema.GroupResource{Resource: "httpchecks"}, name)
+	}
+	w.created = append(w.created, name)
+	return nil
+}
+
+func (w *fakeWriter) Update(ctx context.Context, obj runtime.Object) error {
+	w.updated = append(w.updated, obj.(metav1.Object).GetName())
+	return nil
+}
+
+func (w *fakeWriter) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOptionFunc) error {
+	w.deleted = append(w.deleted, obj.(metav1.Object).GetName())
+	return nil
+}
+
+func TestSyncer_sync(t *testing.T) {
+	owner := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("owner")}}
+	ownerRef := *metav1.NewControllerRef(owner, corev1.SchemeGroupVersion.WithKind("Service"))
+
+	http := func(name string, url string, controlled bool) check {
+		c := &pingdomv1alpha1.HttpCheck{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		c.Spec.URL = url
+		if controlled {
+			c.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		}
+		return httpCheck{c}
+	}
+
+	tests := []struct {
+		name      string
+		existing  []check
+		desired   []check
+		exists    map[string]bool
+		created   []string
+		updated   []string
+		deleted   []string
+		conflicts int
+	}{
+		{
+			"create",
+			nil,
+			[]check{http("web-http", "http://example.com/", true)},
+			nil,
+			[]string{"web-http"},
+			nil,
+			nil,
+			0,
+		},
+		{
+			"update and delete",
+			[]check{http("web-http", "http://example.com/", true), http("web-https", "https://example.com/", true)},
+			[]check{http("web-http", "http://example.com/health", true)},
+			nil,
+			nil,
+			[]string{"web-http"},
+			[]string{"web-https"},
+			0,
+		},
+		{
+			"name taken by a check not controlled by the owner",
+			[]check{http("web-http", "http://example.org/", false)},
+			[]check{http("web-http", "http://example.com/", true), http("web-https", "https://example.com/", true)},
+			nil,
+			[]string{"web-https"},
+			nil,
+			nil,
+			1,
+		},
+		{
+			"name taken after listing",
+			nil,
+			[]check{http("web-http", "http://example.com/", true)},
+			map[string]bool{"web-http": true},
+			nil,
+			nil,
+			nil,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &fakeWriter{exists: tt.exists}
+			recorder := record.NewFakeRecorder(10)
+			s := &Syncer{Client: writer, Recorder: recorder}
+
+			assert.NoError(t, s.sync(owner, tt.existing, tt.desired))
+			assert.Equal(t, tt.created, writer.created)
+			assert.Equal(t, tt.updated, writer.updated)
+			assert.Equal(t, tt.deleted, writer.deleted)
+
+			conflicts := 0
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, EventConflict) {
+					conflicts++
+				}
+			}
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"context"
+	"fmt"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// httpCheckType is the type pingdom reports for http checks
+const httpCheckType = "http"
+
+// adoptionError is returned when an existing pingdom check must not be adopted
+type adoptionError struct {
+	id     int
+	reason string
+}
+
+func (e *adoptionError) Error() string {
+	return fmt.Sprintf("refusing to adopt pingdom check %d: %s", e.id, e.reason)
+}
+
+// adoptableHttpCheck returns the id of an existing pingdom check belonging to the HttpCheck or 0 if there is none.
+// The check is either referenced explicitly by the spec or found by the tags identifying the HttpCheck.
+func (r *ReconcileHttpCheck) adoptableHttpCheck(check *pingdomv1alpha1.HttpCheck) (int, error) {
+	if id := check.Spec.AdoptPingdomID; id != 0 {
+		live, err := r.Service.Read(id)
+		if err != nil {
+			return 0, err
+		}
+
+		err = r.verifyOwnership(check, live)
+		if err != nil {
+			return 0, err
+		}
+
+		return id, nil
+	}
+
+	identity := httpcheck.IdentityTags(options.ClusterName, "HttpCheck", check.Namespace, check.Name)
+
+	candidates, err := httpcheck.ListByTags(r.Service, httpcheck.NameTag(check.Name))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range candidates {
+		candidate := &candidates[i]
+		if !httpcheck.HasTags(candidate, identity...) {
+			continue
+		}
+
+		err := r.verifyOwnership(check, candidate)
+		if err != nil {
+			r.Log.Info("Skipping adoption candidate", "namespace", check.Namespace, "name", check.Name, "reason", err.Error())
+			continue
+		}
+
+		return candidate.ID, nil
+	}
+
+	return 0, nil
+}
+
+// verifyOwnership makes sure the pingdom check is a http check and does not belong to another cluster
+// or another existing HttpCheck. Checks without a cluster tag can be adopted by any cluster.
+func (r *ReconcileHttpCheck) verifyOwnership(check *pingdomv1alpha1.HttpCheck, live *pingdom.CheckResponse) error {
+	if live.Type.Name != httpCheckType {
+		return &adoptionError{id: live.ID, reason: fmt.Sprintf("it is a %s check", live.Type.Name)}
+	}
+
+	var clusterTag string
+	if options.ClusterName != "" {
+		clusterTag = httpcheck.ClusterTag(options.ClusterName)
+	}
+
+	if tag := httpcheck.ClusterTagOf(live); tag != "" && tag != clusterTag {
+		return &adoptionError{id: live.ID, reason: fmt.Sprintf("it is managed by another cluster (%s)", tag)}
+	}
+
+	owner := httpcheck.OwnerUID(live)
+	if owner == "" || owner == string(check.UID) {
+		return nil
+	}
+
+	checks := &pingdomv1alpha1.HttpCheckList{}
+	err := r.List(context.TODO(), &client.ListOptions{}, checks)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range checks.Items {
+		if string(c.UID) == owner {
+			return &adoptionError{id: live.ID, reason: fmt.Sprintf("it is owned by HttpCheck %s/%s", c.Namespace, c.Name)}
+		}
+	}
+
+	return nil
+}
@@ -17,15 +17,19 @@ limitations under the License.
 package httpcheck
 
 import (
-	"context"
-
 	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
 	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
-	"github.com/go-logr/logr"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/probe"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/report"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
 	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -35,7 +39,13 @@ import (
 )
 
 const (
-	finalizer = "finalizer.pingdom.fbsb.io"
+	// Reasons of the status conditions
+	reasonSecretNotFound  = "SecretNotFound"
+	reasonAdoptionRefused = "AdoptionRefused"
+
+	// Reasons of the events
+	eventPublished = "Published"
+	eventWithdrawn = "Withdrawn"
 )
 
 // Add creates a new HttpCheck Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -45,17 +55,35 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
-	return add(mgr, newReconciler(mgr, service))
+	teams, err := team.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	users, err := user.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	probes, err := probe.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	reports, err := report.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, service, teams, users, probes, reports))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, service httpcheck.Service) reconcile.Reconciler {
-	return &ReconcileHttpCheck{
-		Client:  mgr.GetClient(),
-		scheme:  mgr.GetScheme(),
-		service: service,
-		log:     log.Log.WithName("httpcheck-reconciler"),
+func newReconciler(mgr manager.Manager, service httpcheck.Service, teams team.Service, users user.Service, probes probe.Service, reports report.Service) reconcile.Reconciler {
+	r := &ReconcileHttpCheck{
+		teams:   teams,
+		users:   users,
+		probes:  probes,
+		reports: reports,
 	}
+	r.CheckReconciler = common.NewCheckReconciler(mgr, "httpcheck", r, service)
+	return r
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -72,166 +100,242 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to secrets referenced by a HttpCheck
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &common.SecretMapper{
+			Client:     mgr.GetClient(),
+			Log:        log.Log.WithName("httpcheck-secret-mapper"),
+			NewList:    func() runtime.Object { return &pingdomv1alpha1.HttpCheckList{} },
+			References: referencesSecret,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 var _ reconcile.Reconciler = &ReconcileHttpCheck{}
+var _ common.CheckKind = &ReconcileHttpCheck{}
+var _ common.CheckHooks = &ReconcileHttpCheck{}
 
-// ReconcileHttpCheck reconciles a HttpCheck object
+// ReconcileHttpCheck reconciles a HttpCheck object. The sync with pingdom is implemented by the embedded
+// common.CheckReconciler, which uses ReconcileHttpCheck to map a HttpCheck to a pingdom http check,
+// to adopt existing checks and to keep the public report up to date.
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=httpchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=httpchecks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=,resources=events,verbs=create;patch
 type ReconcileHttpCheck struct {
-	client.Client
-	scheme  *runtime.Scheme
-	service httpcheck.Service
-	log     logr.Logger
+	*common.CheckReconciler
+	teams   team.Service
+	users   user.Service
+	probes  probe.Service
+	reports report.Service
 }
 
-// Reconcile reads that state of the cluster for a HttpCheck object and makes changes based on the state read
-// and what is in the HttpCheck.Spec
-// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=httpchecks,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=httpchecks/status,verbs=get;update;patch
-func (r *ReconcileHttpCheck) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	r.log.Info("New reconcile request", "request", request)
+// New returns an empty HttpCheck
+func (r *ReconcileHttpCheck) New() common.Object {
+	return &pingdomv1alpha1.HttpCheck{}
+}
+
+// CheckStatus returns the status of the HttpCheck
+func (r *ReconcileHttpCheck) CheckStatus(check common.Object) *pingdomv1alpha1.PingdomCheckStatus {
+	return &check.(*pingdomv1alpha1.HttpCheck).Status.PingdomCheckStatus
+}
 
-	// Fetch the HttpCheck instance
-	check := &pingdomv1alpha1.HttpCheck{}
-	err := r.Get(context.TODO(), request.NamespacedName, check)
+// Desired returns the pingdom http check described by the spec of the HttpCheck
+func (r *ReconcileHttpCheck) Desired(obj common.Object) (pingdom.Check, error) {
+	check := obj.(*pingdomv1alpha1.HttpCheck)
+
+	opts, err := r.httpCheckOptions(check)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// Object not found, return.  Created objects are automatically garbage collected.
-			// For additional cleanup logic use finalizers.
-			return reconcile.Result{}, nil
+		if _, ok := err.(*common.SecretKeyError); ok || errors.IsNotFound(err) {
+			return nil, &common.SpecError{Reason: reasonSecretNotFound, Err: err}
+		}
+		if err == httpcheck.ErrCredentialsInURL {
+			return nil, &common.SpecError{Reason: common.ReasonInvalidSpec, Err: err}
 		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
+		return nil, err
 	}
 
-	if !check.DeletionTimestamp.IsZero() {
-		// The resource is going to be deleted but we need to do some cleanup first
+	pCheck, err := httpcheck.SimpleHttpCheck(check.Spec.Name, check.Spec.URL, opts...)
+	if err != nil {
+		return nil, &common.SpecError{Reason: common.ReasonInvalidSpec, Err: err}
+	}
 
-		err := r.deleteHttpCheck(check)
-		if err != nil {
-			return reconcile.Result{Requeue: true}, err
-		}
+	return pCheck, nil
+}
 
-		removeFinalizer(check)
-		err = r.Update(context.TODO(), check)
-		if err != nil {
-			return reconcile.Result{Requeue: true}, err
-		}
+// Diff returns the fields of the live check which differ from the desired http check
+func (r *ReconcileHttpCheck) Diff(desired pingdom.Check, live *pingdom.CheckResponse) []string {
+	return httpcheck.Diff(desired.(*httpcheck.Check), live)
+}
 
-		return reconcile.Result{}, nil
+// Adopt returns the id of an existing pingdom check belonging to the HttpCheck, see adoptableHttpCheck
+func (r *ReconcileHttpCheck) Adopt(obj common.Object) (int, error) {
+	id, err := r.adoptableHttpCheck(obj.(*pingdomv1alpha1.HttpCheck))
+	if aErr, ok := err.(*adoptionError); ok {
+		return 0, &common.SpecError{Reason: reasonAdoptionRefused, Err: aErr}
 	}
 
-	if !hasFinalizer(check) {
-		// The resource is new so we need to make sure we add our finalizer first
+	return id, err
+}
 
-		addFinalizer(check)
-		err := r.Update(context.TODO(), check)
-		if err != nil {
-			return reconcile.Result{Requeue: true}, err
-		}
+// Synced publishes or withdraws the check from the public report as requested by the spec.
+// The status is trusted to reflect the public report of the check it was read for, so the report is only read
+// if the spec asks for a change or the pingdom id changed, e.g. after adopting a check.
+func (r *ReconcileHttpCheck) Synced(obj common.Object, created bool) error {
+	check := obj.(*pingdomv1alpha1.HttpCheck)
+	id := check.Status.PingdomID
+
+	if created {
+		// a new check is never on the public report, even if the check it replaces was
+		check.Status.PublicReport = false
+		check.Status.PublicReportCheckID = id
+	}
 
-		// The update will trigger the reconciliation again so we might as well just return here
-		return reconcile.Result{}, nil
+	if check.Status.PublicReportCheckID == id && check.Spec.PublicReport == check.Status.PublicReport {
+		return nil
 	}
 
-	err = r.createOrUpdateHttpCheck(check)
+	published, err := report.Published(r.reports, id)
 	if err != nil {
-		return reconcile.Result{Requeue: true}, err
+		return err
+	}
+
+	switch {
+	case check.Spec.PublicReport && !published:
+		_, err := r.reports.PublishCheck(id)
+		if err != nil {
+			return err
+		}
+		r.Recorder.Eventf(check, corev1.EventTypeNormal, eventPublished, "Published pingdom check %d on the public report", id)
+	case !check.Spec.PublicReport && published:
+		_, err := r.reports.WithdrawlCheck(id)
+		if err != nil {
+			return err
+		}
+		r.Recorder.Eventf(check, corev1.EventTypeNormal, eventWithdrawn, "Withdrew pingdom check %d from the public report", id)
 	}
 
-	return reconcile.Result{}, nil
+	check.Status.PublicReport = check.Spec.PublicReport
+	check.Status.PublicReportCheckID = id
+
+	return nil
 }
 
-func (r *ReconcileHttpCheck) deleteHttpCheck(check *pingdomv1alpha1.HttpCheck) error {
-	if check.Status.PingdomID == 0 {
+// Deleting withdraws the check from the public report before it is deleted
+func (r *ReconcileHttpCheck) Deleting(obj common.Object) error {
+	check := obj.(*pingdomv1alpha1.HttpCheck)
+	if !check.Status.PublicReport {
 		return nil
 	}
 
-	_, err := r.service.Delete(check.Status.PingdomID)
-	if err != nil {
-		if _, ok := err.(*pingdom.PingdomError); ok {
-			// just return if pingdom id does not exist
-			return nil
-		}
-
+	_, err := r.reports.WithdrawlCheck(check.Status.PingdomID)
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(check, corev1.EventTypeWarning, common.EventDeleteFailed,
+			"Could not withdraw pingdom check %d from the public report: %s", check.Status.PingdomID, err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *ReconcileHttpCheck) createOrUpdateHttpCheck(check *pingdomv1alpha1.HttpCheck) error {
-	pCheck, err := httpcheck.SimpleHttpCheck(check.Spec.Name, check.Spec.URL)
-	if err != nil {
-		return r.statusFailure(check, err)
+func (r *ReconcileHttpCheck) httpCheckOptions(check *pingdomv1alpha1.HttpCheck) ([]httpcheck.Option, error) {
+	var opts []httpcheck.Option
+
+	tags := httpcheck.ManagedTags(options.ClusterName, "HttpCheck", check.Namespace, check.Name, string(check.UID))
+	opts = append(opts, httpcheck.Tags(append(tags, check.Spec.Tags...)))
+
+	if check.Spec.Resolution != 0 {
+		opts = append(opts, httpcheck.Resolution(check.Spec.Resolution))
 	}
 
-	if check.Status.PingdomID != 0 {
-		_, err := r.service.Update(check.Status.PingdomID, pCheck)
+	if check.Spec.Paused {
+		opts = append(opts, httpcheck.Paused(true))
+	}
+
+	if check.Spec.ResponseTimeThreshold != 0 {
+		opts = append(opts, httpcheck.ResponseTimeThreshold(check.Spec.ResponseTimeThreshold))
+	}
 
-		if err == nil {
-			return r.statusSuccess(check, check.Status.PingdomID)
+	if n := check.Spec.Notifications; n != nil {
+		if n.WhenDown != 0 {
+			opts = append(opts, httpcheck.SendNotificationWhenDown(n.WhenDown))
 		}
 
-		if _, ok := err.(*pingdom.PingdomError); !ok {
-			return err
+		opts = append(opts, httpcheck.NotifyAgainEvery(n.AgainEvery))
+
+		if n.WhenBackUp != nil {
+			opts = append(opts, httpcheck.NotifyWhenBackup(*n.WhenBackUp))
 		}
 	}
 
-	resp, err := r.service.Create(pCheck)
-	if err != nil {
-		if pErr, ok := err.(*pingdom.PingdomError); ok {
-			return r.statusFailure(check, pErr)
+	if f := check.Spec.ProbeFilters; f != nil && f.Region != "" {
+		err := probe.ValidateRegion(r.probes, f.Region)
+		if err != nil {
+			return nil, err
 		}
-		return err
+		opts = append(opts, httpcheck.ProbeFilters(probe.RegionFilter(f.Region)))
 	}
 
-	return r.statusSuccess(check, resp.ID)
-}
+	userIds, err := common.UserIDs(r.users, "users", check.Spec.Users)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *ReconcileHttpCheck) statusFailure(check *pingdomv1alpha1.HttpCheck, err error) error {
-	message := err.Error()
-	if pErr, ok := err.(*pingdom.PingdomError); ok {
-		message = pErr.Message
+	if len(userIds) > 0 {
+		opts = append(opts, httpcheck.UserIds(userIds))
 	}
 
-	check.Status.Error = message
-	check.Status.PingdomStatus = pingdomv1alpha1.StatusFail
-	return r.Status().Update(context.TODO(), check)
-}
+	teamIds, err := common.TeamIDs(r.teams, "teams", check.Spec.Teams)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *ReconcileHttpCheck) statusSuccess(check *pingdomv1alpha1.HttpCheck, id int) error {
-	check.Status.PingdomID = id
-	check.Status.Error = ""
-	check.Status.PingdomStatus = pingdomv1alpha1.StatusSuccess
-	return r.Status().Update(context.TODO(), check)
-}
+	if len(teamIds) > 0 {
+		opts = append(opts, httpcheck.TeamIds(teamIds))
+	}
 
-// Helper functions to manage resource finalizers
+	if len(check.Spec.IntegrationIDs) > 0 {
+		opts = append(opts, httpcheck.IntegrationIds(check.Spec.IntegrationIDs))
+	}
 
-func hasFinalizer(check *pingdomv1alpha1.HttpCheck) bool {
-	for _, fin := range check.Finalizers {
-		if fin == finalizer {
-			return true
-		}
+	if check.Spec.ShouldContain != "" {
+		opts = append(opts, httpcheck.ShouldContain(check.Spec.ShouldContain))
 	}
 
-	return false
-}
+	if check.Spec.ShouldNotContain != "" {
+		opts = append(opts, httpcheck.ShouldNotContain(check.Spec.ShouldNotContain))
+	}
 
-func addFinalizer(check *pingdomv1alpha1.HttpCheck) {
-	check.Finalizers = append(check.Finalizers, finalizer)
-}
+	headers, err := r.requestHeaders(check)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) > 0 {
+		opts = append(opts, httpcheck.RequestHeaders(headers))
+	}
 
-func removeFinalizer(check *pingdomv1alpha1.HttpCheck) {
-	var output []string
+	if check.Spec.PostData != "" {
+		opts = append(opts, httpcheck.PostData(check.Spec.PostData))
+	}
 
-	for _, f := range check.Finalizers {
-		if f != finalizer {
-			output = append(output, f)
+	if check.Spec.BasicAuthSecretRef != nil {
+		// credentials must not be kept in plain text in the url once a secret is used
+		if httpcheck.HasCredentials(check.Spec.URL) {
+			return nil, httpcheck.ErrCredentialsInURL
+		}
+
+		username, password, err := r.basicAuth(check.Namespace, check.Spec.BasicAuthSecretRef)
+		if err != nil {
+			return nil, err
 		}
+		opts = append(opts, httpcheck.BasicAuth(username, password))
 	}
 
-	check.Finalizers = output
+	return opts, nil
 }
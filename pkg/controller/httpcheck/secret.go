@@ -0,0 +1,84 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultUsernameKey = "username"
+	defaultPasswordKey = "password"
+)
+
+// requestHeaders merges the plain request headers with the ones read from secrets
+func (r *ReconcileHttpCheck) requestHeaders(check *pingdomv1alpha1.HttpCheck) (map[string]string, error) {
+	headers := make(map[string]string, len(check.Spec.RequestHeaders)+len(check.Spec.RequestHeadersFrom))
+
+	for name, value := range check.Spec.RequestHeaders {
+		headers[name] = value
+	}
+
+	for _, header := range check.Spec.RequestHeadersFrom {
+		value, err := common.SecretValue(r, check.Namespace, header.SecretKeyRef.Name, header.SecretKeyRef.Key)
+		if err != nil {
+			return nil, err
+		}
+		headers[header.Name] = value
+	}
+
+	return headers, nil
+}
+
+func (r *ReconcileHttpCheck) basicAuth(namespace string, ref *pingdomv1alpha1.BasicAuthSecretReference) (username string, password string, err error) {
+	usernameKey := ref.UsernameKey
+	if usernameKey == "" {
+		usernameKey = defaultUsernameKey
+	}
+
+	passwordKey := ref.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultPasswordKey
+	}
+
+	username, err = common.SecretValue(r, namespace, ref.Name, usernameKey)
+	if err != nil {
+		return
+	}
+
+	password, err = common.SecretValue(r, namespace, ref.Name, passwordKey)
+	return
+}
+
+// referencesSecret returns true if the HttpCheck reads its basic auth credentials or request headers from the secret
+func referencesSecret(obj runtime.Object, name string) bool {
+	check := obj.(*pingdomv1alpha1.HttpCheck)
+
+	if ref := check.Spec.BasicAuthSecretRef; ref != nil && ref.Name == name {
+		return true
+	}
+
+	for _, header := range check.Spec.RequestHeadersFrom {
+		if header.SecretKeyRef.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/generated"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// desiredChecks returns a HttpCheck for every host and path of the Ingress rules.
+// No checks are returned if the Ingress is not annotated.
+func (r *ReconcileIngress) desiredChecks(ing *extv1beta1.Ingress) []*pingdomv1alpha1.HttpCheck {
+	if ing.Annotations[pingdomv1alpha1.CheckAnnotation] != "true" {
+		return nil
+	}
+
+	resolution := generated.Resolution(ing, r.recorder)
+
+	var checks []*pingdomv1alpha1.HttpCheck
+	seen := map[string]bool{}
+	for _, url := range checkURLs(ing) {
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		checks = append(checks, &pingdomv1alpha1.HttpCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            checkName(ing.Name, url),
+				Namespace:       ing.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ing, ingressKind)},
+			},
+			Spec: pingdomv1alpha1.HttpCheckSpec{
+				Name:          fmt.Sprintf("%s/%s %s", ing.Namespace, ing.Name, url),
+				URL:           url,
+				ShouldContain: ing.Annotations[pingdomv1alpha1.CheckShouldContainAnnotation],
+				Resolution:    resolution,
+			},
+		})
+	}
+
+	return checks
+}
+
+// checkURLs returns the urls of all hosts and paths of the Ingress rules. Hosts listed in the tls section
+// are checked with https. Rules without or with a wildcard host are skipped as they cannot be requested.
+func checkURLs(ing *extv1beta1.Ingress) []string {
+	pathOverride, override := ing.Annotations[pingdomv1alpha1.CheckPathAnnotation]
+
+	var urls []string
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" || strings.Contains(rule.Host, "*") {
+			continue
+		}
+
+		scheme := "http"
+		if hasTLS(ing, rule.Host) {
+			scheme = "https"
+		}
+
+		var paths []string
+		switch {
+		case override:
+			paths = []string{pathOverride}
+		case rule.HTTP != nil:
+			for _, p := range rule.HTTP.Paths {
+				paths = append(paths, p.Path)
+			}
+		}
+
+		if len(paths) == 0 {
+			paths = []string{"/"}
+		}
+
+		for _, path := range paths {
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+			urls = append(urls, scheme+"://"+rule.Host+path)
+		}
+	}
+
+	return urls
+}
+
+// hasTLS returns true if the host is covered by the tls section of the Ingress
+func hasTLS(ing *extv1beta1.Ingress, host string) bool {
+	for _, tls := range ing.Spec.TLS {
+		for _, h := range tls.Hosts {
+			if h == host {
+				return true
+			}
+
+			// a wildcard covers a single label only
+			if strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]) &&
+				!strings.Contains(strings.TrimSuffix(host, h[1:]), ".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkName returns a stable name for the check of the url, unique within the checks of the Ingress
+func checkName(ingressName string, url string) string {
+	h := fnv.New32a()
+	h.Write([]byte(url))
+
+	return generated.CheckName(ingressName, fmt.Sprintf("-%08x", h.Sum32()))
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"strings"
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func rule(host string, paths ...string) extv1beta1.IngressRule {
+	r := extv1beta1.IngressRule{Host: host}
+	if len(paths) > 0 {
+		r.HTTP = &extv1beta1.HTTPIngressRuleValue{}
+		for _, p := range paths {
+			r.HTTP.Paths = append(r.HTTP.Paths, extv1beta1.HTTPIngressPath{Path: p})
+		}
+	}
+	return r
+}
+
+func TestCheckURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        extv1beta1.IngressSpec
+		urls        []string
+	}{
+		{
+			"no rules",
+			nil,
+			extv1beta1.IngressSpec{},
+			nil,
+		},
+		{
+			"host without paths",
+			nil,
+			extv1beta1.IngressSpec{Rules: []extv1beta1.IngressRule{rule("example.com")}},
+			[]string{"http://example.com/"},
+		},
+		{
+			"paths",
+			nil,
+			extv1beta1.IngressSpec{Rules: []extv1beta1.IngressRule{rule("example.com", "/api", "web")}},
+			[]string{"http://example.com/api", "http://example.com/web"},
+		},
+		{
+			"skips empty and wildcard hosts",
+			nil,
+			extv1beta1.IngressSpec{Rules: []extv1beta1.IngressRule{rule(""), rule("*.example.com"), rule("www.example.com")}},
+			[]string{"http://www.example.com/"},
+		},
+		{
+			"tls hosts",
+			nil,
+			extv1beta1.IngressSpec{
+				TLS:   []extv1beta1.IngressTLS{{Hosts: []string{"secure.example.com"}}},
+				Rules: []extv1beta1.IngressRule{rule("secure.example.com"), rule("example.com")},
+			},
+			[]string{"https://secure.example.com/", "http://example.com/"},
+		},
+		{
+			"path annotation overrides paths",
+			map[string]string{pingdomv1alpha1.CheckPathAnnotation: "healthz"},
+			extv1beta1.IngressSpec{Rules: []extv1beta1.IngressRule{rule("example.com", "/api", "/web")}},
+			[]string{"http://example.com/healthz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &extv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       tt.spec,
+			}
+			assert.Equal(t, tt.urls, checkURLs(ing))
+		})
+	}
+}
+
+func TestHasTLS(t *testing.T) {
+	ing := &extv1beta1.Ingress{Spec: extv1beta1.IngressSpec{TLS: []extv1beta1.IngressTLS{
+		{Hosts: []string{"example.com"}},
+		{Hosts: []string{"*.apps.example.com"}},
+	}}}
+
+	tests := []struct {
+		name string
+		host string
+		tls  bool
+	}{
+		{
+			"listed host",
+			"example.com",
+			true,
+		},
+		{
+			"unlisted host",
+			"www.example.com",
+			false,
+		},
+		{
+			"wildcard",
+			"web.apps.example.com",
+			true,
+		},
+		{
+			"wildcard covers a single label only",
+			"a.web.apps.example.com",
+			false,
+		},
+		{
+			"wildcard does not cover the domain",
+			"apps.example.com",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.tls, hasTLS(ing, tt.host))
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	name := checkName("web", "http://example.com/")
+
+	assert.True(t, strings.HasPrefix(name, "web-"))
+	assert.Equal(t, name, checkName("web", "http://example.com/"))
+	assert.NotEqual(t, name, checkName("web", "https://example.com/"))
+
+	long := checkName(strings.Repeat("a", validation.DNS1123SubdomainMaxLength), "http://example.com/")
+	assert.Len(t, long, validation.DNS1123SubdomainMaxLength)
+	assert.Empty(t, validation.IsDNS1123Subdomain(long))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/generated"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/go-logr/logr"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ingressKind is the kind of the owner references of generated checks
+var ingressKind = extv1beta1.SchemeGroupVersion.WithKind("Ingress")
+
+// Add creates a new Ingress Controller and adds it to the Manager if the generation of checks for Ingresses is enabled.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+// Only Ingresses of the extensions/v1beta1 API are watched: the vendored k8s.io/api does not contain the
+// networking.k8s.io API group, which is served for Ingresses since kubernetes 1.14.
+func Add(mgr manager.Manager) error {
+	if !options.IngressChecks {
+		return nil
+	}
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileIngress{
+		Client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetRecorder("ingress-controller"),
+		log:      log.Log.WithName("ingress-reconciler"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("ingress-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Ingress
+	err = c.Watch(&source.Kind{Type: &extv1beta1.Ingress{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to HttpChecks generated for an Ingress
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.HttpCheck{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &extv1beta1.Ingress{},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileIngress{}
+
+// ReconcileIngress generates HttpChecks for annotated Ingress objects
+type ReconcileIngress struct {
+	client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for an Ingress object and creates, updates or deletes
+// the HttpChecks generated for its rules
+// +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch
+func (r *ReconcileIngress) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.log.Info("New reconcile request", "request", request)
+
+	// Fetch the Ingress instance
+	ing := &extv1beta1.Ingress{}
+	err := r.Get(context.TODO(), request.NamespacedName, ing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return. Generated checks are garbage collected by their owner reference.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !ing.DeletionTimestamp.IsZero() {
+		// Generated checks are garbage collected once the Ingress is gone
+		return reconcile.Result{}, nil
+	}
+
+	syncer := &generated.Syncer{Client: r.Client, Recorder: r.recorder}
+	err = syncer.SyncHttpChecks(ing, r.desiredChecks(ing))
+	if err != nil {
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// checkIDs returns the sorted pingdom ids of all HttpChecks selected by the window.
+// HttpChecks which have not been created in pingdom yet are skipped.
+func (r *ReconcileMaintenanceWindow) checkIDs(window *pingdomv1alpha1.MaintenanceWindow, selector labels.Selector) ([]int, error) {
+	opts := client.InNamespace(window.Namespace)
+	opts.LabelSelector = selector
+
+	checks := &pingdomv1alpha1.HttpCheckList{}
+	err := r.List(context.TODO(), opts, checks)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, check := range checks.Items {
+		if check.Status.PingdomID != 0 && check.DeletionTimestamp.IsZero() {
+			ids = append(ids, check.Status.PingdomID)
+		}
+	}
+
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+// checkChanged passes the updates of HttpChecks which can change the checks of a MaintenanceWindow, i.e. changes of
+// the labels or the pingdom id and the start of the deletion. Other updates, e.g. of the live status, are dropped.
+var checkChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldCheck, ok := e.ObjectOld.(*pingdomv1alpha1.HttpCheck)
+		if !ok {
+			return true
+		}
+
+		newCheck, ok := e.ObjectNew.(*pingdomv1alpha1.HttpCheck)
+		if !ok {
+			return true
+		}
+
+		return !reflect.DeepEqual(oldCheck.Labels, newCheck.Labels) ||
+			oldCheck.Status.PingdomID != newCheck.Status.PingdomID ||
+			oldCheck.DeletionTimestamp.IsZero() != newCheck.DeletionTimestamp.IsZero()
+	},
+}
+
+// checkMapper maps a HttpCheck to reconcile requests for all MaintenanceWindows in its namespace.
+// All windows are reconciled since a check might just have stopped matching a selector.
+type checkMapper struct {
+	client.Client
+	log logr.Logger
+}
+
+var _ handler.Mapper = &checkMapper{}
+
+func (m *checkMapper) Map(obj handler.MapObject) []reconcile.Request {
+	windows := &pingdomv1alpha1.MaintenanceWindowList{}
+	err := m.List(context.TODO(), client.InNamespace(obj.Meta.GetNamespace()), windows)
+	if err != nil {
+		m.log.Error(err, "could not list maintenancewindows for httpcheck", "namespace", obj.Meta.GetNamespace(), "name", obj.Meta.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, window := range windows.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: window.Namespace, Name: window.Name},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func httpCheck(labels map[string]string, id int, deleted bool) *pingdomv1alpha1.HttpCheck {
+	check := &pingdomv1alpha1.HttpCheck{ObjectMeta: metav1.ObjectMeta{Name: "example", Labels: labels}}
+	check.Status.PingdomID = id
+	if deleted {
+		now := metav1.Now()
+		check.DeletionTimestamp = &now
+	}
+	return check
+}
+
+func TestCheckChanged(t *testing.T) {
+	web := map[string]string{"tier": "web"}
+
+	tests := []struct {
+		name     string
+		old      *pingdomv1alpha1.HttpCheck
+		new      *pingdomv1alpha1.HttpCheck
+		enqueued bool
+	}{
+		{
+			"status updated",
+			httpCheck(web, 1, false),
+			func() *pingdomv1alpha1.HttpCheck {
+				c := httpCheck(web, 1, false)
+				c.Status.CheckStatus = "down"
+				return c
+			}(),
+			false,
+		},
+		{
+			"labels changed",
+			httpCheck(web, 1, false),
+			httpCheck(map[string]string{"tier": "db"}, 1, false),
+			true,
+		},
+		{
+			"labels removed",
+			httpCheck(web, 1, false),
+			httpCheck(nil, 1, false),
+			true,
+		},
+		{
+			"created in pingdom",
+			httpCheck(web, 0, false),
+			httpCheck(web, 1, false),
+			true,
+		},
+		{
+			"deleted",
+			httpCheck(web, 1, false),
+			httpCheck(web, 1, true),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event.UpdateEvent{MetaOld: tt.old, ObjectOld: tt.old, MetaNew: tt.new, ObjectNew: tt.new}
+			assert.Equal(t, tt.enqueued, checkChanged.Update(e))
+		})
+	}
+}
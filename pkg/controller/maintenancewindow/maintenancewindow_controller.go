@@ -0,0 +1,346 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/maintenance"
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// Reasons of the status conditions
+	reasonNoChecks    = "NoChecks"
+	reasonInvalidSpec = "InvalidSpec"
+
+	// Reasons of the events
+	eventCreated      = "Created"
+	eventUpdated      = "Updated"
+	eventDeleted      = "Deleted"
+	eventDeleteFailed = "DeleteFailed"
+)
+
+// Add creates a new MaintenanceWindow Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	service, err := maintenance.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, service))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, service maintenance.Service) reconcile.Reconciler {
+	return &ReconcileMaintenanceWindow{
+		Client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		service:  service,
+		recorder: mgr.GetRecorder("maintenancewindow-controller"),
+		log:      log.Log.WithName("maintenancewindow-reconciler"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("maintenancewindow-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to MaintenanceWindow
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.MaintenanceWindow{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for HttpChecks joining or leaving a MaintenanceWindow
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.HttpCheck{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &checkMapper{
+			Client: mgr.GetClient(),
+			log:    log.Log.WithName("maintenancewindow-check-mapper"),
+		},
+	}, checkChanged)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileMaintenanceWindow{}
+
+// ReconcileMaintenanceWindow reconciles a MaintenanceWindow object
+type ReconcileMaintenanceWindow struct {
+	client.Client
+	scheme   *runtime.Scheme
+	service  maintenance.Service
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for a MaintenanceWindow object and makes changes based on the state read
+// and what is in the MaintenanceWindow.Spec
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=maintenancewindows,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=maintenancewindows/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=httpchecks,verbs=get;list;watch
+func (r *ReconcileMaintenanceWindow) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.log.Info("New reconcile request", "request", request)
+
+	// Fetch the MaintenanceWindow instance
+	window := &pingdomv1alpha1.MaintenanceWindow{}
+	err := r.Get(context.TODO(), request.NamespacedName, window)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !window.DeletionTimestamp.IsZero() {
+		// The resource is going to be deleted but we need to do some cleanup first
+
+		err := r.deleteMaintenanceWindow(window)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		common.RemoveFinalizer(window)
+		err = r.Update(context.TODO(), window)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if !common.HasFinalizer(window) {
+		// The resource is new so we need to make sure we add our finalizer first
+
+		common.AddFinalizer(window)
+		err := r.Update(context.TODO(), window)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		// The update will trigger the reconciliation again so we might as well just return here
+		return reconcile.Result{}, nil
+	}
+
+	err = r.createOrUpdateMaintenanceWindow(window)
+	return common.Result(err, common.ResyncPeriod(window, r.log))
+}
+
+func (r *ReconcileMaintenanceWindow) deleteMaintenanceWindow(window *pingdomv1alpha1.MaintenanceWindow) error {
+	if window.Status.PingdomID == 0 {
+		return nil
+	}
+
+	_, err := r.service.Delete(window.Status.PingdomID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// just return if pingdom id does not exist
+			return nil
+		}
+
+		r.recorder.Eventf(window, corev1.EventTypeWarning, eventDeleteFailed,
+			"Could not delete pingdom maintenance window %d: %s", window.Status.PingdomID, err)
+		return err
+	}
+
+	r.recorder.Eventf(window, corev1.EventTypeNormal, eventDeleted, "Deleted pingdom maintenance window %d", window.Status.PingdomID)
+
+	return nil
+}
+
+func (r *ReconcileMaintenanceWindow) createOrUpdateMaintenanceWindow(window *pingdomv1alpha1.MaintenanceWindow) error {
+	selector, err := metav1.LabelSelectorAsSelector(&window.Spec.CheckSelector)
+	if err != nil {
+		return r.statusFailure(window, err)
+	}
+
+	ids, err := r.checkIDs(window, selector)
+	if err != nil {
+		return err
+	}
+
+	window.Status.CheckIDs = ids
+
+	// Pingdom does not allow to remove all checks from a window, so a window without checks is deleted
+	// and created again as soon as a check matches the selector.
+	if len(ids) == 0 {
+		err := r.deleteMaintenanceWindow(window)
+		if err != nil {
+			return r.pingdomFailure(window, err)
+		}
+
+		return r.statusSuccess(window, 0, reasonNoChecks, "No HttpCheck with a pingdom check matches the check selector")
+	}
+
+	pWindow, err := maintenance.SimpleMaintenanceWindow(description(window), window.Spec.Start.Time, window.Spec.End.Time, ids, windowOptions(window)...)
+	if err != nil {
+		return r.statusFailure(window, err)
+	}
+
+	if window.Status.PingdomID != 0 {
+		err := r.syncMaintenanceWindow(window, pWindow)
+
+		if err == nil {
+			return r.statusSynced(window, window.Status.PingdomID)
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return r.pingdomFailure(window, err)
+		}
+
+		// The window was deleted in pingdom so we need to create it again
+		r.log.Info("Pingdom maintenance window not found, creating a new one", "namespace", window.Namespace, "name", window.Name, "pingdomId", window.Status.PingdomID)
+	}
+
+	resp, err := r.service.Create(pWindow)
+	if err != nil {
+		return r.pingdomFailure(window, err)
+	}
+
+	r.recorder.Eventf(window, corev1.EventTypeNormal, eventCreated, "Created pingdom maintenance window %d", resp.ID)
+
+	return r.statusSynced(window, resp.ID)
+}
+
+// syncMaintenanceWindow reads the window from pingdom and updates it if it differs from the desired window
+func (r *ReconcileMaintenanceWindow) syncMaintenanceWindow(window *pingdomv1alpha1.MaintenanceWindow, pWindow *pingdom.MaintenanceWindow) error {
+	live, err := r.service.Read(window.Status.PingdomID)
+	if err != nil {
+		return err
+	}
+
+	diff := maintenance.Diff(pWindow, live)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	_, err = r.service.Update(window.Status.PingdomID, pWindow)
+	if err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(window, corev1.EventTypeNormal, eventUpdated,
+		"Updated %s of pingdom maintenance window %d", strings.Join(diff, ", "), window.Status.PingdomID)
+
+	return nil
+}
+
+// description returns the description of the window in pingdom
+func description(window *pingdomv1alpha1.MaintenanceWindow) string {
+	if window.Spec.Description != "" {
+		return window.Spec.Description
+	}
+
+	return fmt.Sprintf("%s/%s", window.Namespace, window.Name)
+}
+
+func windowOptions(window *pingdomv1alpha1.MaintenanceWindow) []maintenance.Option {
+	var opts []maintenance.Option
+
+	if rec := window.Spec.Recurrence; rec != nil && rec.Type != maintenance.RecurrenceNone {
+		every := rec.RepeatEvery
+		if every == 0 {
+			every = 1
+		}
+
+		var until time.Time
+		if rec.Until != nil {
+			until = rec.Until.Time
+		}
+
+		opts = append(opts, maintenance.Recurrence(rec.Type, every, until))
+	}
+
+	return opts
+}
+
+func (r *ReconcileMaintenanceWindow) statusFailure(window *pingdomv1alpha1.MaintenanceWindow, err error) error {
+	message := common.ErrorMessage(err)
+	reason := reasonInvalidSpec
+	if _, ok := err.(*pingdom.PingdomError); ok {
+		reason = string(apierrors.ReasonForError(err))
+	}
+
+	window.Status.Error = message
+	window.Status.PingdomStatus = pingdomv1alpha1.StatusFail
+	window.Status.ObservedGeneration = window.Generation
+
+	if window.Status.PingdomID != 0 {
+		r.recorder.Eventf(window, corev1.EventTypeWarning, reason, "Could not sync pingdom maintenance window %d: %s", window.Status.PingdomID, message)
+	} else {
+		r.recorder.Eventf(window, corev1.EventTypeWarning, reason, "Could not create pingdom maintenance window: %s", message)
+	}
+	r.setConditions(window, corev1.ConditionFalse, reason, message)
+
+	return r.Status().Update(context.TODO(), window)
+}
+
+// pingdomFailure records a failed pingdom api request in the status and decides how the request is retried
+func (r *ReconcileMaintenanceWindow) pingdomFailure(window *pingdomv1alpha1.MaintenanceWindow, err error) error {
+	return common.PingdomFailure(err, func(err error) error {
+		return r.statusFailure(window, err)
+	})
+}
+
+func (r *ReconcileMaintenanceWindow) statusSynced(window *pingdomv1alpha1.MaintenanceWindow, id int) error {
+	return r.statusSuccess(window, id, common.ReasonSynced, fmt.Sprintf("Pingdom maintenance window %d is up to date", id))
+}
+
+func (r *ReconcileMaintenanceWindow) statusSuccess(window *pingdomv1alpha1.MaintenanceWindow, id int, reason string, message string) error {
+	window.Status.PingdomID = id
+	window.Status.Error = ""
+	window.Status.PingdomStatus = pingdomv1alpha1.StatusSuccess
+	window.Status.ObservedGeneration = window.Generation
+
+	r.setConditions(window, corev1.ConditionTrue, reason, message)
+
+	return r.Status().Update(context.TODO(), window)
+}
+
+// setConditions sets the ready and synced conditions to the given status
+func (r *ReconcileMaintenanceWindow) setConditions(window *pingdomv1alpha1.MaintenanceWindow, status corev1.ConditionStatus, reason string, message string) {
+	window.Status.Conditions = common.SetConditions(window.Status.Conditions, status, reason, message)
+}
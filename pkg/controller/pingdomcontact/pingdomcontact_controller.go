@@ -0,0 +1,389 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingdomcontact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// Reasons of the status conditions
+	reasonInvalidSpec    = "InvalidSpec"
+	reasonSecretNotFound = "SecretNotFound"
+
+	// Reasons of the events
+	eventCreated      = "Created"
+	eventUpdated      = "Updated"
+	eventDeleted      = "Deleted"
+	eventAdopted      = "Adopted"
+	eventDeleteFailed = "DeleteFailed"
+)
+
+// Add creates a new PingdomContact Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	service, err := user.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, service))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, service user.Service) reconcile.Reconciler {
+	return &ReconcilePingdomContact{
+		Client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		service:  service,
+		recorder: mgr.GetRecorder("pingdomcontact-controller"),
+		log:      log.Log.WithName("pingdomcontact-reconciler"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("pingdomcontact-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to PingdomContact
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.PingdomContact{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secrets referenced by a PingdomContact
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &common.SecretMapper{
+			Client:     mgr.GetClient(),
+			Log:        log.Log.WithName("pingdomcontact-secret-mapper"),
+			NewList:    func() runtime.Object { return &pingdomv1alpha1.PingdomContactList{} },
+			References: referencesSecret,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePingdomContact{}
+
+// ReconcilePingdomContact reconciles a PingdomContact object
+type ReconcilePingdomContact struct {
+	client.Client
+	scheme   *runtime.Scheme
+	service  user.Service
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for a PingdomContact object and makes changes based on the state read
+// and what is in the PingdomContact.Spec
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=pingdomcontacts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=pingdomcontacts/status,verbs=get;update;patch
+func (r *ReconcilePingdomContact) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.log.Info("New reconcile request", "request", request)
+
+	// Fetch the PingdomContact instance
+	pc := &pingdomv1alpha1.PingdomContact{}
+	err := r.Get(context.TODO(), request.NamespacedName, pc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !pc.DeletionTimestamp.IsZero() {
+		// The resource is going to be deleted but we need to do some cleanup first
+
+		err := r.deletePingdomContact(pc)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		common.RemoveFinalizer(pc)
+		err = r.Update(context.TODO(), pc)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if !common.HasFinalizer(pc) {
+		// The resource is new so we need to make sure we add our finalizer first
+
+		common.AddFinalizer(pc)
+		err := r.Update(context.TODO(), pc)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		// The update will trigger the reconciliation again so we might as well just return here
+		return reconcile.Result{}, nil
+	}
+
+	err = r.createOrUpdatePingdomContact(pc)
+	return common.Result(err, common.ResyncPeriod(pc, r.log))
+}
+
+func (r *ReconcilePingdomContact) deletePingdomContact(pc *pingdomv1alpha1.PingdomContact) error {
+	if pc.Status.PingdomID == 0 {
+		return nil
+	}
+
+	// Deleting the user removes all of its contact targets as well
+	_, err := r.service.Delete(pc.Status.PingdomID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// just return if pingdom id does not exist
+			return nil
+		}
+
+		r.recorder.Eventf(pc, corev1.EventTypeWarning, eventDeleteFailed,
+			"Could not delete pingdom user %d: %s", pc.Status.PingdomID, err)
+		return err
+	}
+
+	r.recorder.Eventf(pc, corev1.EventTypeNormal, eventDeleted, "Deleted pingdom user %d", pc.Status.PingdomID)
+
+	return nil
+}
+
+func (r *ReconcilePingdomContact) createOrUpdatePingdomContact(pc *pingdomv1alpha1.PingdomContact) error {
+	contacts, err := r.contacts(pc)
+	if err != nil {
+		if !invalidSpec(err) {
+			// e.g. the secret could not be read, which is retried with backoff
+			return err
+		}
+		return r.statusFailure(pc, err)
+	}
+
+	pUser, err := user.SimpleUser(pc.Spec.Name)
+	if err != nil {
+		return r.statusFailure(pc, err)
+	}
+
+	var live *pingdom.UsersResponse
+	if pc.Status.PingdomID != 0 {
+		live, err = user.ByID(r.service, pc.Status.PingdomID)
+		if err != nil {
+			return r.pingdomFailure(pc, err)
+		}
+
+		if live == nil {
+			// The user was deleted in pingdom so we need to create it again
+			r.log.Info("Pingdom user not found, creating a new one", "namespace", pc.Namespace, "name", pc.Name, "pingdomId", pc.Status.PingdomID)
+		}
+	}
+
+	if live == nil {
+		// Adopt a user with the same name instead of creating a duplicate, e.g. if the id of a user
+		// created by an earlier sync could not be saved in the status
+		live, err = user.ByName(r.service, pUser.Username)
+		if err != nil {
+			return r.pingdomFailure(pc, err)
+		}
+
+		if live != nil {
+			pc.Status.PingdomID = live.Id
+			r.recorder.Eventf(pc, corev1.EventTypeNormal, eventAdopted, "Adopted existing pingdom user %d", live.Id)
+		}
+	}
+
+	changes, err := user.PlanContacts(contacts, live)
+	if err != nil {
+		return r.statusFailure(pc, err)
+	}
+
+	if live != nil {
+		err = r.updatePingdomUser(pc, pUser, live)
+	} else {
+		err = r.createPingdomUser(pc, pUser)
+	}
+	if err != nil {
+		return r.pingdomFailure(pc, err)
+	}
+
+	err = r.applyContactChanges(pc, changes)
+	if err != nil {
+		return r.pingdomFailure(pc, err)
+	}
+
+	if live != nil && !changes.Empty() {
+		r.recorder.Eventf(pc, corev1.EventTypeNormal, eventUpdated,
+			"Updated contact targets of pingdom user %d: %d created, %d updated, %d deleted",
+			pc.Status.PingdomID, len(changes.Create), len(changes.Update), len(changes.Delete))
+	}
+
+	return r.statusSuccess(pc, pc.Status.PingdomID)
+}
+
+// createPingdomUser creates the user and records its id in the status right away,
+// so it is not created again if adding its contact targets fails
+func (r *ReconcilePingdomContact) createPingdomUser(pc *pingdomv1alpha1.PingdomContact, pUser *pingdom.User) error {
+	created, err := r.service.Create(pUser)
+	if err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(pc, corev1.EventTypeNormal, eventCreated, "Created pingdom user %d", created.Id)
+
+	pc.Status.PingdomID = created.Id
+	return r.Status().Update(context.TODO(), pc)
+}
+
+// updatePingdomUser updates the user if it differs from the desired user
+func (r *ReconcilePingdomContact) updatePingdomUser(pc *pingdomv1alpha1.PingdomContact, pUser *pingdom.User, live *pingdom.UsersResponse) error {
+	diff := user.Diff(pUser, live)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	_, err := r.service.Update(live.Id, pUser)
+	if err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(pc, corev1.EventTypeNormal, eventUpdated,
+		"Updated %s of pingdom user %d", strings.Join(diff, ", "), live.Id)
+
+	return nil
+}
+
+// applyContactChanges creates, updates and deletes the contact targets of the user
+func (r *ReconcilePingdomContact) applyContactChanges(pc *pingdomv1alpha1.PingdomContact, changes user.ContactChanges) error {
+	id := pc.Status.PingdomID
+
+	for _, contact := range changes.Create {
+		_, err := r.service.CreateContact(id, contact)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, update := range changes.Update {
+		_, err := r.service.UpdateContact(id, update.ID, update.Contact)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, contactID := range changes.Delete {
+		_, err := r.service.DeleteContact(id, contactID)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidSpec returns true if the error is caused by the spec or a referenced secret and is not fixed by a retry
+func invalidSpec(err error) bool {
+	switch err {
+	case user.ErrEmptyEmail, user.ErrEmptyNumber, user.ErrEmptyCountryCode, user.ErrInvalidSeverity:
+		return true
+	}
+
+	if _, ok := err.(*common.SecretKeyError); ok {
+		return true
+	}
+
+	return errors.IsNotFound(err)
+}
+
+func (r *ReconcilePingdomContact) statusFailure(pc *pingdomv1alpha1.PingdomContact, err error) error {
+	message := common.ErrorMessage(err)
+	reason := reasonInvalidSpec
+	switch err.(type) {
+	case *pingdom.PingdomError:
+		reason = string(apierrors.ReasonForError(err))
+	case *common.SecretKeyError:
+		reason = reasonSecretNotFound
+	default:
+		if errors.IsNotFound(err) {
+			reason = reasonSecretNotFound
+		}
+	}
+
+	pc.Status.Error = message
+	pc.Status.PingdomStatus = pingdomv1alpha1.StatusFail
+	pc.Status.ObservedGeneration = pc.Generation
+
+	if pc.Status.PingdomID != 0 {
+		r.recorder.Eventf(pc, corev1.EventTypeWarning, reason, "Could not sync pingdom user %d: %s", pc.Status.PingdomID, message)
+	} else {
+		r.recorder.Eventf(pc, corev1.EventTypeWarning, reason, "Could not create pingdom user: %s", message)
+	}
+	r.setConditions(pc, corev1.ConditionFalse, reason, message)
+
+	return r.Status().Update(context.TODO(), pc)
+}
+
+// pingdomFailure records a failed pingdom api request in the status and decides how the request is retried
+func (r *ReconcilePingdomContact) pingdomFailure(pc *pingdomv1alpha1.PingdomContact, err error) error {
+	return common.PingdomFailure(err, func(err error) error {
+		return r.statusFailure(pc, err)
+	})
+}
+
+func (r *ReconcilePingdomContact) statusSuccess(pc *pingdomv1alpha1.PingdomContact, id int) error {
+	pc.Status.PingdomID = id
+	pc.Status.Error = ""
+	pc.Status.PingdomStatus = pingdomv1alpha1.StatusSuccess
+	pc.Status.ObservedGeneration = pc.Generation
+
+	message := fmt.Sprintf("Pingdom user %d is up to date", id)
+	r.setConditions(pc, corev1.ConditionTrue, common.ReasonSynced, message)
+
+	return r.Status().Update(context.TODO(), pc)
+}
+
+// setConditions sets the ready and synced conditions to the given status
+func (r *ReconcilePingdomContact) setConditions(pc *pingdomv1alpha1.PingdomContact, status corev1.ConditionStatus, reason string, message string) {
+	pc.Status.Conditions = common.SetConditions(pc.Status.Conditions, status, reason, message)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingdomcontact
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestInvalidSpec(t *testing.T) {
+	secrets := schema.GroupResource{Resource: "secrets"}
+
+	tests := []struct {
+		name    string
+		err     error
+		invalid bool
+	}{
+		{"invalid contact", user.ErrInvalidSeverity, true},
+		{"missing secret key", &common.SecretKeyError{Name: "phone", Key: "number"}, true},
+		{"missing secret", apierrors.NewNotFound(secrets, "phone"), true},
+		{"secret not readable", apierrors.NewForbidden(secrets, "phone", errors.New("denied")), false},
+		{"timeout", apierrors.NewTimeoutError("timeout", 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.invalid, invalidSpec(tt.err))
+		})
+	}
+}
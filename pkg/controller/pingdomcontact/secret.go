@@ -0,0 +1,66 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingdomcontact
+
+import (
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// contacts returns the desired contact targets, reading the phone numbers from their secrets
+func (r *ReconcilePingdomContact) contacts(pc *pingdomv1alpha1.PingdomContact) ([]pingdom.Contact, error) {
+	contacts := make([]pingdom.Contact, 0, len(pc.Spec.Emails)+len(pc.Spec.SMS))
+
+	for _, email := range pc.Spec.Emails {
+		contact, err := user.EmailContact(email.Address, email.Severity)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	for _, sms := range pc.Spec.SMS {
+		number, err := common.SecretValue(r.Client, pc.Namespace, sms.NumberSecretRef.Name, sms.NumberSecretRef.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		contact, err := user.SMSContact(sms.CountryCode, number, sms.Provider, sms.Severity)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, nil
+}
+
+// referencesSecret returns true if the PingdomContact reads a phone number from the secret
+func referencesSecret(obj runtime.Object, name string) bool {
+	pc := obj.(*pingdomv1alpha1.PingdomContact)
+
+	for _, sms := range pc.Spec.SMS {
+		if sms.NumberSecretRef.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
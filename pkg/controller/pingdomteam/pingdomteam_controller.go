@@ -0,0 +1,304 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingdomteam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/apierrors"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// Reasons of the status conditions
+	reasonInvalidSpec    = "InvalidSpec"
+	reasonMemberNotFound = "MemberNotFound"
+
+	// Reasons of the events
+	eventCreated      = "Created"
+	eventUpdated      = "Updated"
+	eventDeleted      = "Deleted"
+	eventDeleteFailed = "DeleteFailed"
+)
+
+// Add creates a new PingdomTeam Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	service, err := team.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	users, err := user.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, service, users))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, service team.Service, users user.Service) reconcile.Reconciler {
+	return &ReconcilePingdomTeam{
+		Client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		service:  service,
+		users:    users,
+		recorder: mgr.GetRecorder("pingdomteam-controller"),
+		log:      log.Log.WithName("pingdomteam-reconciler"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("pingdomteam-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to PingdomTeam
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.PingdomTeam{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePingdomTeam{}
+
+// ReconcilePingdomTeam reconciles a PingdomTeam object
+type ReconcilePingdomTeam struct {
+	client.Client
+	scheme   *runtime.Scheme
+	service  team.Service
+	users    user.Service
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for a PingdomTeam object and makes changes based on the state read
+// and what is in the PingdomTeam.Spec
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=pingdomteams,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=pingdomteams/status,verbs=get;update;patch
+func (r *ReconcilePingdomTeam) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.log.Info("New reconcile request", "request", request)
+
+	// Fetch the PingdomTeam instance
+	pt := &pingdomv1alpha1.PingdomTeam{}
+	err := r.Get(context.TODO(), request.NamespacedName, pt)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !pt.DeletionTimestamp.IsZero() {
+		// The resource is going to be deleted but we need to do some cleanup first
+
+		err := r.deletePingdomTeam(pt)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		common.RemoveFinalizer(pt)
+		err = r.Update(context.TODO(), pt)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if !common.HasFinalizer(pt) {
+		// The resource is new so we need to make sure we add our finalizer first
+
+		common.AddFinalizer(pt)
+		err := r.Update(context.TODO(), pt)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, err
+		}
+
+		// The update will trigger the reconciliation again so we might as well just return here
+		return reconcile.Result{}, nil
+	}
+
+	err = r.createOrUpdatePingdomTeam(pt)
+	return common.Result(err, common.ResyncPeriod(pt, r.log))
+}
+
+func (r *ReconcilePingdomTeam) deletePingdomTeam(pt *pingdomv1alpha1.PingdomTeam) error {
+	if pt.Status.PingdomID == 0 {
+		return nil
+	}
+
+	_, err := r.service.Delete(pt.Status.PingdomID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// just return if pingdom id does not exist
+			return nil
+		}
+
+		r.recorder.Eventf(pt, corev1.EventTypeWarning, eventDeleteFailed,
+			"Could not delete pingdom team %d: %s", pt.Status.PingdomID, err)
+		return err
+	}
+
+	r.recorder.Eventf(pt, corev1.EventTypeNormal, eventDeleted, "Deleted pingdom team %d", pt.Status.PingdomID)
+
+	return nil
+}
+
+func (r *ReconcilePingdomTeam) createOrUpdatePingdomTeam(pt *pingdomv1alpha1.PingdomTeam) error {
+	userIDs, err := common.UserIDs(r.users, "members", pt.Spec.Members)
+	if err != nil {
+		switch err.(type) {
+		case *user.NotFoundError, *common.InvalidReferenceError:
+			return r.statusFailure(pt, err)
+		case *pingdom.PingdomError:
+			return r.pingdomFailure(pt, err)
+		}
+		return err
+	}
+
+	pTeam, err := team.SimpleTeam(pt.Spec.Name, userIDs)
+	if err != nil {
+		return r.statusFailure(pt, err)
+	}
+
+	if pt.Status.PingdomID != 0 {
+		err := r.syncPingdomTeam(pt, pTeam)
+
+		if err == nil {
+			return r.statusSuccess(pt, pt.Status.PingdomID)
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return r.pingdomFailure(pt, err)
+		}
+
+		// The team was deleted in pingdom so we need to create it again
+		r.log.Info("Pingdom team not found, creating a new one", "namespace", pt.Namespace, "name", pt.Name, "pingdomId", pt.Status.PingdomID)
+	}
+
+	resp, err := r.service.Create(pTeam)
+	if err != nil {
+		return r.pingdomFailure(pt, err)
+	}
+
+	id, err := strconv.Atoi(resp.ID)
+	if err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(pt, corev1.EventTypeNormal, eventCreated, "Created pingdom team %d", id)
+
+	return r.statusSuccess(pt, id)
+}
+
+// syncPingdomTeam reads the team from pingdom and updates it if it differs from the desired team
+func (r *ReconcilePingdomTeam) syncPingdomTeam(pt *pingdomv1alpha1.PingdomTeam, pTeam *team.Team) error {
+	live, err := r.service.Read(pt.Status.PingdomID)
+	if err != nil {
+		return err
+	}
+
+	diff := team.Diff(pTeam, live)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	_, err = r.service.Update(pt.Status.PingdomID, pTeam)
+	if err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(pt, corev1.EventTypeNormal, eventUpdated,
+		"Updated %s of pingdom team %d", strings.Join(diff, ", "), pt.Status.PingdomID)
+
+	return nil
+}
+
+func (r *ReconcilePingdomTeam) statusFailure(pt *pingdomv1alpha1.PingdomTeam, err error) error {
+	message := common.ErrorMessage(err)
+	reason := reasonInvalidSpec
+	switch err.(type) {
+	case *pingdom.PingdomError:
+		reason = string(apierrors.ReasonForError(err))
+	case *user.NotFoundError:
+		reason = reasonMemberNotFound
+	}
+
+	pt.Status.Error = message
+	pt.Status.PingdomStatus = pingdomv1alpha1.StatusFail
+	pt.Status.ObservedGeneration = pt.Generation
+
+	if pt.Status.PingdomID != 0 {
+		r.recorder.Eventf(pt, corev1.EventTypeWarning, reason, "Could not sync pingdom team %d: %s", pt.Status.PingdomID, message)
+	} else {
+		r.recorder.Eventf(pt, corev1.EventTypeWarning, reason, "Could not create pingdom team: %s", message)
+	}
+	r.setConditions(pt, corev1.ConditionFalse, reason, message)
+
+	return r.Status().Update(context.TODO(), pt)
+}
+
+// pingdomFailure records a failed pingdom api request in the status and decides how the request is retried
+func (r *ReconcilePingdomTeam) pingdomFailure(pt *pingdomv1alpha1.PingdomTeam, err error) error {
+	return common.PingdomFailure(err, func(err error) error {
+		return r.statusFailure(pt, err)
+	})
+}
+
+func (r *ReconcilePingdomTeam) statusSuccess(pt *pingdomv1alpha1.PingdomTeam, id int) error {
+	pt.Status.PingdomID = id
+	pt.Status.Error = ""
+	pt.Status.PingdomStatus = pingdomv1alpha1.StatusSuccess
+	pt.Status.ObservedGeneration = pt.Generation
+
+	message := fmt.Sprintf("Pingdom team %d is up to date", id)
+	r.setConditions(pt, corev1.ConditionTrue, common.ReasonSynced, message)
+
+	return r.Status().Update(context.TODO(), pt)
+}
+
+// setConditions sets the ready and synced conditions to the given status
+func (r *ReconcilePingdomTeam) setConditions(pt *pingdomv1alpha1.PingdomTeam, status corev1.ConditionStatus, reason string, message string) {
+	pt.Status.Conditions = common.SetConditions(pt.Status.Conditions, status, reason, message)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/generated"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Protocols of the checks generated for a port
+const (
+	protocolHTTP  = "http"
+	protocolHTTPS = "https"
+	protocolTCP   = "tcp"
+)
+
+// checkPort is a port of the Service selected for a check
+type checkPort struct {
+	port     int
+	protocol string
+}
+
+// desiredChecks returns a check for every annotated port of the Service. No checks are returned if the Service
+// is not annotated or not of type LoadBalancer. It returns false if the load balancer has no address yet.
+func (r *ReconcileService) desiredChecks(svc *corev1.Service) ([]*pingdomv1alpha1.HttpCheck, []*pingdomv1alpha1.TCPCheck, bool) {
+	if svc.Annotations[pingdomv1alpha1.CheckAnnotation] != "true" || svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return nil, nil, true
+	}
+
+	host := loadBalancerAddress(svc)
+	if host == "" {
+		return nil, nil, false
+	}
+
+	resolution := generated.Resolution(svc, r.recorder)
+
+	var httpChecks []*pingdomv1alpha1.HttpCheck
+	var tcpChecks []*pingdomv1alpha1.TCPCheck
+	for _, p := range r.checkPorts(svc) {
+		meta := metav1.ObjectMeta{
+			Name:            checkName(svc.Name, p),
+			Namespace:       svc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(svc, serviceKind)},
+		}
+
+		if p.protocol == protocolTCP {
+			tcpChecks = append(tcpChecks, &pingdomv1alpha1.TCPCheck{
+				ObjectMeta: meta,
+				Spec: pingdomv1alpha1.TCPCheckSpec{
+					Name:       fmt.Sprintf("%s/%s %s", svc.Namespace, svc.Name, net.JoinHostPort(host, strconv.Itoa(p.port))),
+					Host:       host,
+					Port:       p.port,
+					Resolution: resolution,
+				},
+			})
+			continue
+		}
+
+		url := checkURL(p, host, svc.Annotations[pingdomv1alpha1.CheckPathAnnotation])
+		httpChecks = append(httpChecks, &pingdomv1alpha1.HttpCheck{
+			ObjectMeta: meta,
+			Spec: pingdomv1alpha1.HttpCheckSpec{
+				Name:          fmt.Sprintf("%s/%s %s", svc.Namespace, svc.Name, url),
+				URL:           url,
+				ShouldContain: svc.Annotations[pingdomv1alpha1.CheckShouldContainAnnotation],
+				Resolution:    resolution,
+			},
+		})
+	}
+
+	return httpChecks, tcpChecks, true
+}
+
+// loadBalancerAddress returns the first ip or hostname assigned to the load balancer of the Service
+func loadBalancerAddress(svc *corev1.Service) string {
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+
+	return ""
+}
+
+// checkPorts returns the ports listed in the ports annotation. Invalid entries are reported as events and skipped.
+func (r *ReconcileService) checkPorts(svc *corev1.Service) []checkPort {
+	var ports []checkPort
+	seen := map[checkPort]bool{}
+
+	for _, entry := range strings.Split(svc.Annotations[pingdomv1alpha1.CheckPortsAnnotation], ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		ref, protocol := entry, protocolTCP
+		if i := strings.Index(entry, "/"); i >= 0 {
+			ref, protocol = entry[:i], strings.ToLower(entry[i+1:])
+		}
+
+		switch protocol {
+		case protocolHTTP, protocolHTTPS, protocolTCP:
+		default:
+			r.recorder.Eventf(svc, corev1.EventTypeWarning, generated.EventInvalidAnnotation,
+				"Ignoring check port %q, the protocol must be one of http, https or tcp", entry)
+			continue
+		}
+
+		port, ok := servicePort(svc, ref)
+		if !ok {
+			r.recorder.Eventf(svc, corev1.EventTypeWarning, generated.EventInvalidAnnotation,
+				"Ignoring check port %q, the service has no tcp port %q", entry, ref)
+			continue
+		}
+
+		p := checkPort{port: port, protocol: protocol}
+		if !seen[p] {
+			seen[p] = true
+			ports = append(ports, p)
+		}
+	}
+
+	return ports
+}
+
+// servicePort returns the number of the tcp port of the Service selected by name or number
+func servicePort(svc *corev1.Service, ref string) (int, bool) {
+	for _, p := range svc.Spec.Ports {
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+
+		if p.Name == ref || strconv.Itoa(int(p.Port)) == ref {
+			return int(p.Port), true
+		}
+	}
+
+	return 0, false
+}
+
+// checkURL returns the url requested by the http check of the port, omitting default ports
+func checkURL(p checkPort, host string, path string) string {
+	hostPort := net.JoinHostPort(host, strconv.Itoa(p.port))
+	if (p.protocol == protocolHTTP && p.port == 80) || (p.protocol == protocolHTTPS && p.port == 443) {
+		hostPort = host
+		if strings.Contains(host, ":") {
+			hostPort = "[" + host + "]"
+		}
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return p.protocol + "://" + hostPort + path
+}
+
+// checkName returns a stable name for the check of the port, so address changes update the existing check
+func checkName(serviceName string, p checkPort) string {
+	return generated.CheckName(serviceName, fmt.Sprintf("-%d-%s", p.port, p.protocol))
+}
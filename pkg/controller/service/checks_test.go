@@ -0,0 +1,197 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestCheckPorts(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP},
+		{Name: "https", Port: 443},
+		{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+	}
+
+	tests := []struct {
+		name       string
+		annotation string
+		ports      []checkPort
+		events     int
+	}{
+		{
+			"no annotation",
+			"",
+			nil,
+			0,
+		},
+		{
+			"named and numbered ports",
+			"http/http, 443/https",
+			[]checkPort{{80, protocolHTTP}, {443, protocolHTTPS}},
+			0,
+		},
+		{
+			"defaults to tcp",
+			"https",
+			[]checkPort{{443, protocolTCP}},
+			0,
+		},
+		{
+			"protocol is case insensitive",
+			"http/HTTP",
+			[]checkPort{{80, protocolHTTP}},
+			0,
+		},
+		{
+			"invalid protocol",
+			"http/ftp,https/https",
+			[]checkPort{{443, protocolHTTPS}},
+			1,
+		},
+		{
+			"unknown port",
+			"8080/http,http/http",
+			[]checkPort{{80, protocolHTTP}},
+			1,
+		},
+		{
+			"skips udp ports",
+			"dns,53",
+			nil,
+			2,
+		},
+		{
+			"duplicates",
+			"http/http,80/http,,http",
+			[]checkPort{{80, protocolHTTP}, {80, protocolTCP}},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileService{recorder: recorder}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{pingdomv1alpha1.CheckPortsAnnotation: tt.annotation}},
+				Spec:       corev1.ServiceSpec{Ports: ports},
+			}
+
+			assert.Equal(t, tt.ports, r.checkPorts(svc))
+			assert.Len(t, recorder.Events, tt.events)
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		name string
+		port checkPort
+		host string
+		path string
+		url  string
+	}{
+		{
+			"default http port",
+			checkPort{80, protocolHTTP},
+			"1.2.3.4",
+			"",
+			"http://1.2.3.4/",
+		},
+		{
+			"default https port",
+			checkPort{443, protocolHTTPS},
+			"lb.example.com",
+			"/healthz",
+			"https://lb.example.com/healthz",
+		},
+		{
+			"other port",
+			checkPort{8443, protocolHTTPS},
+			"lb.example.com",
+			"healthz",
+			"https://lb.example.com:8443/healthz",
+		},
+		{
+			"http on the https port",
+			checkPort{443, protocolHTTP},
+			"lb.example.com",
+			"/",
+			"http://lb.example.com:443/",
+		},
+		{
+			"ipv6 default port",
+			checkPort{80, protocolHTTP},
+			"2001:db8::1",
+			"/",
+			"http://[2001:db8::1]/",
+		},
+		{
+			"ipv6 other port",
+			checkPort{8080, protocolHTTP},
+			"2001:db8::1",
+			"/",
+			"http://[2001:db8::1]:8080/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.url, checkURL(tt.port, tt.host, tt.path))
+		})
+	}
+}
+
+func TestLoadBalancerAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress []corev1.LoadBalancerIngress
+		address string
+	}{
+		{
+			"no address",
+			nil,
+			"",
+		},
+		{
+			"ip",
+			[]corev1.LoadBalancerIngress{{IP: "1.2.3.4", Hostname: "lb.example.com"}},
+			"1.2.3.4",
+		},
+		{
+			"hostname",
+			[]corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+			"lb.example.com",
+		},
+		{
+			"first assigned address",
+			[]corev1.LoadBalancerIngress{{}, {Hostname: "lb.example.com"}, {IP: "1.2.3.4"}},
+			"lb.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: tt.ingress}}}
+			assert.Equal(t, tt.address, loadBalancerAddress(svc))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/generated"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// serviceKind is the kind of the owner references of generated checks
+var serviceKind = corev1.SchemeGroupVersion.WithKind("Service")
+
+// Add creates a new Service Controller and adds it to the Manager if the generation of checks for Services is enabled.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	if !options.ServiceChecks {
+		return nil
+	}
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileService{
+		Client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetRecorder("service-controller"),
+		log:      log.Log.WithName("service-reconciler"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("service-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Service
+	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to checks generated for a Service
+	for _, t := range []runtime.Object{&pingdomv1alpha1.HttpCheck{}, &pingdomv1alpha1.TCPCheck{}} {
+		err = c.Watch(&source.Kind{Type: t}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &corev1.Service{},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileService{}
+
+// ReconcileService generates HttpChecks and TCPChecks for annotated Services of type LoadBalancer
+type ReconcileService struct {
+	client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for a Service object and creates, updates or deletes
+// the checks generated for its load balancer
+// +kubebuilder:rbac:groups=,resources=services,verbs=get;list;watch
+func (r *ReconcileService) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.log.Info("New reconcile request", "request", request)
+
+	// Fetch the Service instance
+	svc := &corev1.Service{}
+	err := r.Get(context.TODO(), request.NamespacedName, svc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return. Generated checks are garbage collected by their owner reference.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !svc.DeletionTimestamp.IsZero() {
+		// Generated checks are garbage collected once the Service is gone
+		return reconcile.Result{}, nil
+	}
+
+	httpChecks, tcpChecks, ok := r.desiredChecks(svc)
+	if !ok {
+		// The status update assigning the address will trigger the reconciliation again
+		r.log.Info("Waiting for the load balancer address", "namespace", svc.Namespace, "name", svc.Name)
+		return reconcile.Result{}, nil
+	}
+
+	syncer := &generated.Syncer{Client: r.Client, Recorder: r.recorder}
+	err = syncer.SyncHttpChecks(svc, httpChecks)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	err = syncer.SyncTCPChecks(svc, tcpChecks)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	return reconcile.Result{}, nil
+}
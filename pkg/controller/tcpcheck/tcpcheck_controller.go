@@ -0,0 +1,193 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpcheck
+
+import (
+	pingdomv1alpha1 "github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/fbsb/pingdom-operator/pkg/controller/common"
+	"github.com/fbsb/pingdom-operator/pkg/options"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/probe"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/tcpcheck"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/team"
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/user"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new TCPCheck Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	service, err := httpcheck.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	teams, err := team.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	users, err := user.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	probes, err := probe.ServiceInstance()
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, service, teams, users, probes))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, service httpcheck.Service, teams team.Service, users user.Service, probes probe.Service) reconcile.Reconciler {
+	r := &ReconcileTCPCheck{
+		teams:  teams,
+		users:  users,
+		probes: probes,
+	}
+	r.CheckReconciler = common.NewCheckReconciler(mgr, "tcpcheck", r, service)
+	return r
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("tcpcheck-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to TCPCheck
+	err = c.Watch(&source.Kind{Type: &pingdomv1alpha1.TCPCheck{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileTCPCheck{}
+var _ common.CheckKind = &ReconcileTCPCheck{}
+
+// ReconcileTCPCheck reconciles a TCPCheck object. The sync with pingdom is implemented by the embedded
+// common.CheckReconciler, which uses ReconcileTCPCheck to map a TCPCheck to a pingdom tcp check.
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=tcpchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=pingdom.fbsb.io,resources=tcpchecks/status,verbs=get;update;patch
+type ReconcileTCPCheck struct {
+	*common.CheckReconciler
+	teams  team.Service
+	users  user.Service
+	probes probe.Service
+}
+
+// New returns an empty TCPCheck
+func (r *ReconcileTCPCheck) New() common.Object {
+	return &pingdomv1alpha1.TCPCheck{}
+}
+
+// CheckStatus returns the status of the TCPCheck
+func (r *ReconcileTCPCheck) CheckStatus(check common.Object) *pingdomv1alpha1.PingdomCheckStatus {
+	return &check.(*pingdomv1alpha1.TCPCheck).Status.PingdomCheckStatus
+}
+
+// Desired returns the pingdom tcp check described by the spec of the TCPCheck
+func (r *ReconcileTCPCheck) Desired(obj common.Object) (pingdom.Check, error) {
+	check := obj.(*pingdomv1alpha1.TCPCheck)
+
+	opts, err := r.tcpCheckOptions(check)
+	if err != nil {
+		return nil, err
+	}
+
+	pCheck, err := tcpcheck.SimpleTCPCheck(check.Spec.Name, check.Spec.Host, check.Spec.Port, opts...)
+	if err != nil {
+		return nil, &common.SpecError{Reason: common.ReasonInvalidSpec, Err: err}
+	}
+
+	pCheck.StringToSend = check.Spec.StringToSend
+	pCheck.StringToExpect = check.Spec.StringToExpect
+
+	return pCheck, nil
+}
+
+// Diff returns the fields of the live check which differ from the desired tcp check
+func (r *ReconcileTCPCheck) Diff(desired pingdom.Check, live *pingdom.CheckResponse) []string {
+	return tcpcheck.Diff(desired.(*tcpcheck.Check), live)
+}
+
+func (r *ReconcileTCPCheck) tcpCheckOptions(check *pingdomv1alpha1.TCPCheck) ([]checkutil.Option, error) {
+	var opts []checkutil.Option
+
+	tags := httpcheck.ManagedTags(options.ClusterName, "TCPCheck", check.Namespace, check.Name, string(check.UID))
+	opts = append(opts, checkutil.Tags(append(tags, check.Spec.Tags...)))
+
+	if check.Spec.Resolution != 0 {
+		opts = append(opts, checkutil.Resolution(check.Spec.Resolution))
+	}
+
+	if check.Spec.Paused {
+		opts = append(opts, checkutil.Paused(true))
+	}
+
+	if n := check.Spec.Notifications; n != nil {
+		if n.WhenDown != 0 {
+			opts = append(opts, checkutil.SendNotificationWhenDown(n.WhenDown))
+		}
+
+		opts = append(opts, checkutil.NotifyAgainEvery(n.AgainEvery))
+
+		if n.WhenBackUp != nil {
+			opts = append(opts, checkutil.NotifyWhenBackup(*n.WhenBackUp))
+		}
+	}
+
+	if f := check.Spec.ProbeFilters; f != nil && f.Region != "" {
+		err := probe.ValidateRegion(r.probes, f.Region)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, checkutil.ProbeFilters(probe.RegionFilter(f.Region)))
+	}
+
+	userIds, err := common.UserIDs(r.users, "users", check.Spec.Users)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userIds) > 0 {
+		opts = append(opts, checkutil.UserIds(userIds))
+	}
+
+	teamIds, err := common.TeamIDs(r.teams, "teams", check.Spec.Teams)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(teamIds) > 0 {
+		opts = append(opts, checkutil.TeamIds(teamIds))
+	}
+
+	if len(check.Spec.IntegrationIDs) > 0 {
+		opts = append(opts, checkutil.IntegrationIds(check.Spec.IntegrationIDs))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exports the results of managed pingdom checks as prometheus metrics
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/httpcheck"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("metrics")
+
+var labels = []string{"kind", "namespace", "name", "pingdom_id"}
+
+var (
+	checkUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pingdom_check_up",
+		Help: "Whether the pingdom check is up (1) or down (0). Paused checks and checks without results are not reported.",
+	}, labels)
+
+	checkResponseTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pingdom_check_last_response_time_seconds",
+		Help: "Response time of the last test run of the pingdom check.",
+	}, labels)
+
+	checkUptime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pingdom_check_uptime_percent",
+		Help: "Uptime of the pingdom check in percent over the summary period of pingdom.",
+	}, labels)
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(checkUp, checkResponseTime, checkUptime)
+}
+
+// Collector periodically polls the results of all checks managed by HttpChecks, TCPChecks and PingChecks and
+// exports them as gauges.
+// The requests of a poll share the rate limit of the check service with the controllers, so large numbers of checks
+// do not exhaust the pingdom API limits needed by the controllers.
+type Collector struct {
+	client.Client
+	service  httpcheck.Service
+	interval time.Duration
+	log      logr.Logger
+
+	// uptime keeps the last known uptime by pingdom id, in case a summary could not be fetched
+	uptime map[int]float64
+}
+
+// NewCollector returns a collector polling the checks every interval
+func NewCollector(c client.Client, service httpcheck.Service, interval time.Duration) *Collector {
+	return &Collector{
+		Client:   c,
+		service:  service,
+		interval: interval,
+		log:      log,
+		uptime:   map[int]float64{},
+	}
+}
+
+// Start implements manager.Runnable and polls the checks until stop is closed
+func (c *Collector) Start(stop <-chan struct{}) error {
+	if c.interval <= 0 {
+		c.log.Info("polling of check results is disabled")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	poll := func() {
+		if err := c.poll(ctx); err != nil && ctx.Err() == nil {
+			c.log.Error(err, "could not poll check results")
+		}
+	}
+
+	// the manager starts runnables after the caches synced, so the results can be exported right away
+	poll()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+// poll fetches the results of all managed checks and replaces the exported gauges
+func (c *Collector) poll(ctx context.Context) error {
+	checks, err := listChecks(ctx, c.Client)
+	if err != nil {
+		return err
+	}
+
+	responses, err := httpcheck.ListByTags(c.service, httpcheck.ManagedTag)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[int]pingdom.CheckResponse, len(responses))
+	for _, r := range responses {
+		live[r.ID] = r
+	}
+
+	// gauges are only replaced after all summaries have been fetched, which might take a while with a low rate limit
+	var samples []sample
+	uptime := make(map[int]float64, len(checks))
+
+	for _, check := range checks {
+		r, ok := live[check.pingdomID]
+		if !ok {
+			continue
+		}
+
+		s := sample{
+			labels: prometheus.Labels{
+				"kind":       check.kind,
+				"namespace":  check.namespace,
+				"name":       check.name,
+				"pingdom_id": strconv.Itoa(r.ID),
+			},
+			response: r,
+		}
+
+		s.uptime, s.hasUptime = c.uptimePercent(r.ID)
+		if s.hasUptime {
+			uptime[r.ID] = s.uptime
+		}
+
+		samples = append(samples, s)
+	}
+
+	c.uptime = uptime
+
+	checkUp.Reset()
+	checkResponseTime.Reset()
+	checkUptime.Reset()
+
+	for _, s := range samples {
+		s.export()
+	}
+
+	return nil
+}
+
+// sample holds the results of a single check of a poll
+type sample struct {
+	labels    prometheus.Labels
+	response  pingdom.CheckResponse
+	uptime    float64
+	hasUptime bool
+}
+
+func (s sample) export() {
+	switch s.response.Status {
+	case "up":
+		checkUp.With(s.labels).Set(1)
+	case "down", "unconfirmed_down":
+		checkUp.With(s.labels).Set(0)
+	}
+
+	if s.response.LastTestTime != 0 {
+		checkResponseTime.With(s.labels).Set(float64(s.response.LastResponseTime) / 1000)
+	}
+
+	if s.hasUptime {
+		checkUptime.With(s.labels).Set(s.uptime)
+	}
+}
+
+// uptimePercent fetches the performance summary of the check and falls back to the last known uptime on errors
+func (c *Collector) uptimePercent(id int) (float64, bool) {
+	summary, err := c.service.SummaryPerformance(pingdom.SummaryPerformanceRequest{
+		Id:            id,
+		Resolution:    "day",
+		IncludeUptime: true,
+	})
+	if err != nil {
+		c.log.Error(err, "could not get performance summary", "pingdomId", id)
+		percent, ok := c.uptime[id]
+		return percent, ok
+	}
+
+	return UptimePercent(summary.Summary.Days)
+}
+
+// UptimePercent returns the share of monitored time the check was up. It returns false if the check was never monitored.
+func UptimePercent(summaries []pingdom.SummaryPerformanceSummary) (float64, bool) {
+	var up, down int
+	for _, s := range summaries {
+		up += s.Uptime
+		down += s.Downtime
+	}
+
+	if up+down == 0 {
+		return 0, false
+	}
+
+	return float64(up) / float64(up+down) * 100, true
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptimePercent(t *testing.T) {
+	tests := []struct {
+		name      string
+		summaries []pingdom.SummaryPerformanceSummary
+		percent   float64
+		ok        bool
+	}{
+		{
+			"no summaries",
+			nil,
+			0,
+			false,
+		},
+		{
+			"unmonitored",
+			[]pingdom.SummaryPerformanceSummary{{Unmonitored: 86400}},
+			0,
+			false,
+		},
+		{
+			"always up",
+			[]pingdom.SummaryPerformanceSummary{{Uptime: 86400}, {Uptime: 86400}},
+			100,
+			true,
+		},
+		{
+			"partially down",
+			[]pingdom.SummaryPerformanceSummary{{Uptime: 86400}, {Uptime: 43200, Downtime: 43200}},
+			75,
+			true,
+		},
+		{
+			"ignores unmonitored time",
+			[]pingdom.SummaryPerformanceSummary{{Uptime: 43200, Downtime: 43200, Unmonitored: 86400}},
+			50,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, ok := UptimePercent(tt.summaries)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.percent, percent, 0.001)
+		})
+	}
+}
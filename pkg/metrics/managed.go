@@ -0,0 +1,151 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Sync states of managed checks as reported by the managed checks gauge
+const (
+	syncStateSynced  = "synced"
+	syncStateFailed  = "failed"
+	syncStatePending = "pending"
+)
+
+var managedChecksDesc = prometheus.NewDesc(
+	"pingdom_managed_checks",
+	"Number of check resources managed by the operator by kind and sync state. The state is taken from the Synced condition of the resources.",
+	[]string{"kind", "state"},
+	nil,
+)
+
+// managedCheck identifies a check resource and the pingdom check it manages
+type managedCheck struct {
+	kind       string
+	namespace  string
+	name       string
+	pingdomID  int
+	conditions []v1alpha1.Condition
+}
+
+// listChecks lists the check resources of all kinds
+func listChecks(ctx context.Context, c client.Reader) ([]managedCheck, error) {
+	var checks []managedCheck
+
+	httpChecks := &v1alpha1.HttpCheckList{}
+	if err := c.List(ctx, &client.ListOptions{}, httpChecks); err != nil {
+		return nil, err
+	}
+	for _, check := range httpChecks.Items {
+		checks = append(checks, managedCheck{"HttpCheck", check.Namespace, check.Name, check.Status.PingdomID, check.Status.Conditions})
+	}
+
+	tcpChecks := &v1alpha1.TCPCheckList{}
+	if err := c.List(ctx, &client.ListOptions{}, tcpChecks); err != nil {
+		return nil, err
+	}
+	for _, check := range tcpChecks.Items {
+		checks = append(checks, managedCheck{"TCPCheck", check.Namespace, check.Name, check.Status.PingdomID, check.Status.Conditions})
+	}
+
+	pingChecks := &v1alpha1.PingCheckList{}
+	if err := c.List(ctx, &client.ListOptions{}, pingChecks); err != nil {
+		return nil, err
+	}
+	for _, check := range pingChecks.Items {
+		checks = append(checks, managedCheck{"PingCheck", check.Namespace, check.Name, check.Status.PingdomID, check.Status.Conditions})
+	}
+
+	return checks, nil
+}
+
+// ManagedChecks counts the check resources by their sync state whenever the metrics are scraped.
+// It reads the resources from the client, which should be backed by the cache of the manager.
+// The gauge is not part of the instrumented check service, as the service only sees single requests and
+// cannot tell which resource they belong to or whether the resource is in sync with pingdom.
+type ManagedChecks struct {
+	client client.Reader
+}
+
+// NewManagedChecks returns a prometheus collector for the managed checks gauge
+func NewManagedChecks(c client.Reader) *ManagedChecks {
+	return &ManagedChecks{client: c}
+}
+
+// Describe implements prometheus.Collector
+func (m *ManagedChecks) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedChecksDesc
+}
+
+// Collect implements prometheus.Collector
+func (m *ManagedChecks) Collect(ch chan<- prometheus.Metric) {
+	checks, err := listChecks(context.Background(), m.client)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(managedChecksDesc, err)
+		return
+	}
+
+	for kind, states := range countStates(checks) {
+		for state, count := range states {
+			ch <- prometheus.MustNewConstMetric(managedChecksDesc, prometheus.GaugeValue, float64(count), kind, state)
+		}
+	}
+}
+
+// countStates counts the checks by kind and sync state. All states of all kinds are included, so the
+// series do not disappear when no check is in a state.
+func countStates(checks []managedCheck) map[string]map[string]int {
+	counts := map[string]map[string]int{}
+	for _, kind := range []string{"HttpCheck", "TCPCheck", "PingCheck"} {
+		counts[kind] = map[string]int{
+			syncStateSynced:  0,
+			syncStateFailed:  0,
+			syncStatePending: 0,
+		}
+	}
+
+	for _, check := range checks {
+		counts[check.kind][syncState(check.conditions)]++
+	}
+
+	return counts
+}
+
+// syncState returns the sync state of a check from its Synced condition.
+// Checks which have not been synced yet are pending.
+func syncState(conditions []v1alpha1.Condition) string {
+	for _, c := range conditions {
+		if c.Type != v1alpha1.ConditionSynced {
+			continue
+		}
+
+		switch c.Status {
+		case corev1.ConditionTrue:
+			return syncStateSynced
+		case corev1.ConditionFalse:
+			return syncStateFailed
+		}
+	}
+
+	return syncStatePending
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/fbsb/pingdom-operator/pkg/apis/pingdom/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSyncState(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []v1alpha1.Condition
+		state      string
+	}{
+		{
+			"no conditions",
+			nil,
+			syncStatePending,
+		},
+		{
+			"synced",
+			[]v1alpha1.Condition{{Type: v1alpha1.ConditionReady, Status: corev1.ConditionTrue}, {Type: v1alpha1.ConditionSynced, Status: corev1.ConditionTrue}},
+			syncStateSynced,
+		},
+		{
+			"failed",
+			[]v1alpha1.Condition{{Type: v1alpha1.ConditionSynced, Status: corev1.ConditionFalse}},
+			syncStateFailed,
+		},
+		{
+			"unknown",
+			[]v1alpha1.Condition{{Type: v1alpha1.ConditionSynced, Status: corev1.ConditionUnknown}},
+			syncStatePending,
+		},
+		{
+			"ignores other conditions",
+			[]v1alpha1.Condition{{Type: v1alpha1.ConditionDegraded, Status: corev1.ConditionTrue}},
+			syncStatePending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.state, syncState(tt.conditions))
+		})
+	}
+}
+
+func TestCountStates(t *testing.T) {
+	synced := []v1alpha1.Condition{{Type: v1alpha1.ConditionSynced, Status: corev1.ConditionTrue}}
+	failed := []v1alpha1.Condition{{Type: v1alpha1.ConditionSynced, Status: corev1.ConditionFalse}}
+
+	counts := countStates([]managedCheck{
+		{kind: "HttpCheck", conditions: synced},
+		{kind: "HttpCheck", conditions: synced},
+		{kind: "HttpCheck", conditions: failed},
+		{kind: "PingCheck"},
+	})
+
+	assert.Equal(t, map[string]map[string]int{
+		"HttpCheck": {syncStateSynced: 2, syncStateFailed: 1, syncStatePending: 0},
+		"TCPCheck":  {syncStateSynced: 0, syncStateFailed: 0, syncStatePending: 0},
+		"PingCheck": {syncStateSynced: 0, syncStateFailed: 0, syncStatePending: 1},
+	}, counts)
+}
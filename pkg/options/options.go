@@ -0,0 +1,63 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the operator settings shared by all controllers
+package options
+
+import (
+	"time"
+)
+
+const (
+	// DefaultResyncPeriod is the default interval in which managed objects are synced with pingdom
+	DefaultResyncPeriod = 10 * time.Minute
+
+	// DefaultMetricsPollInterval is the default interval in which check results are polled from pingdom
+	DefaultMetricsPollInterval = 5 * time.Minute
+
+	// DefaultRateLimit is the default number of requests per minute to the pingdom checks API
+	DefaultRateLimit = 60
+)
+
+var (
+	// ClusterName identifies the cluster the operator runs in. It is added as a tag to all managed checks.
+	ClusterName string
+
+	// ConfigHashKey keys the hashes of the configurations applied to pingdom which are stored in the status of checks.
+	// It is derived from the pingdom credentials, so changing them causes a single update of every check.
+	ConfigHashKey []byte
+
+	// ResyncPeriod is the interval in which managed objects are synced with pingdom even if they did not change.
+	// Each resync also refreshes the live state of the checks reported in their status.
+	// A period of zero disables the periodic resync.
+	ResyncPeriod = DefaultResyncPeriod
+
+	// MetricsPollInterval is the interval in which the results of all managed checks are polled from pingdom and
+	// exported as prometheus metrics. An interval of zero disables the polling.
+	MetricsPollInterval = DefaultMetricsPollInterval
+
+	// RateLimit is the number of requests per minute to the pingdom checks API shared by the controllers and the
+	// polls of check results, so large numbers of checks do not exhaust the limits of the pingdom API.
+	// A limit of zero disables the rate limit.
+	RateLimit = DefaultRateLimit
+
+	// IngressChecks enables the generation of HttpChecks for annotated Ingresses.
+	// Only Ingresses of the extensions/v1beta1 API are watched, networking.k8s.io is not supported yet.
+	IngressChecks bool
+
+	// ServiceChecks enables the generation of HttpChecks and TCPChecks for annotated Services of type LoadBalancer.
+	ServiceChecks bool
+)
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apierrors classifies the errors returned by the pingdom api
+package apierrors
+
+import (
+	"net/http"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Reason describes why a pingdom api request failed
+type Reason string
+
+const (
+	ReasonNotFound     Reason = "NotFound"
+	ReasonInvalid      Reason = "Invalid"
+	ReasonUnauthorized Reason = "Unauthorized"
+	ReasonRateLimited  Reason = "RateLimited"
+	ReasonServerError  Reason = "ServerError"
+	ReasonUnknown      Reason = "Unknown"
+)
+
+// ReasonForError returns the reason of a pingdom api error.
+// Errors which are not returned by the pingdom api have an unknown reason.
+func ReasonForError(err error) Reason {
+	pErr, ok := err.(*pingdom.PingdomError)
+	if !ok {
+		return ReasonUnknown
+	}
+
+	switch code := pErr.StatusCode; {
+	case code == http.StatusNotFound:
+		return ReasonNotFound
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return ReasonInvalid
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ReasonUnauthorized
+	case code == http.StatusTooManyRequests:
+		return ReasonRateLimited
+	case code >= http.StatusInternalServerError:
+		return ReasonServerError
+	}
+
+	return ReasonUnknown
+}
+
+// IsNotFound returns true if the requested pingdom object does not exist
+func IsNotFound(err error) bool {
+	return ReasonForError(err) == ReasonNotFound
+}
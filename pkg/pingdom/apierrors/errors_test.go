@@ -0,0 +1,79 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apierrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReasonForError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason Reason
+	}{
+		{
+			"not a pingdom error",
+			errors.New("connection refused"),
+			ReasonUnknown,
+		},
+		{
+			"not found",
+			&pingdom.PingdomError{StatusCode: 404, StatusDesc: "Not Found"},
+			ReasonNotFound,
+		},
+		{
+			"bad request",
+			&pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request"},
+			ReasonInvalid,
+		},
+		{
+			"unauthorized",
+			&pingdom.PingdomError{StatusCode: 401, StatusDesc: "Unauthorized"},
+			ReasonUnauthorized,
+		},
+		{
+			"forbidden",
+			&pingdom.PingdomError{StatusCode: 403, StatusDesc: "Forbidden"},
+			ReasonUnauthorized,
+		},
+		{
+			"rate limited",
+			&pingdom.PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"},
+			ReasonRateLimited,
+		},
+		{
+			"server error",
+			&pingdom.PingdomError{StatusCode: 503, StatusDesc: "Service Unavailable"},
+			ReasonServerError,
+		},
+		{
+			"unexpected status",
+			&pingdom.PingdomError{StatusCode: 409, StatusDesc: "Conflict"},
+			ReasonUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reason, ReasonForError(tt.err))
+		})
+	}
+}
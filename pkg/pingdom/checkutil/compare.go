@@ -0,0 +1,114 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkutil contains the helpers shared by all kinds of pingdom checks
+package checkutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// ConfigHash returns a keyed hash of the request parameters of a check. It identifies the configuration applied to pingdom,
+// including fields which cannot be compared with the check read from pingdom. The parameters may contain credentials
+// read from secrets, so the hash is keyed to keep readers of the status from guessing them.
+func ConfigHash(key []byte, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := hmac.New(sha256.New, key)
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(params[key]))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// EqualIntSets returns true if both slices contain the same ids, regardless of their order
+func EqualIntSets(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sa := append([]int(nil), a...)
+	sb := append([]int(nil), b...)
+	sort.Ints(sa)
+	sort.Ints(sb)
+
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UniqueIDs returns the ids of all slices in their order, keeping only the first occurrence of every id
+func UniqueIDs(ids ...[]int) []int {
+	var unique []int
+	seen := map[int]bool{}
+	for _, s := range ids {
+		for _, id := range s {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// EqualTags returns true if the comma separated desired tags are the same as the tags of the live check
+func EqualTags(desired string, live []pingdom.CheckResponseTag) bool {
+	var a []string
+	if desired != "" {
+		a = strings.Split(desired, ",")
+	}
+
+	b := make([]string, 0, len(live))
+	for _, tag := range live {
+		b = append(b, tag.Name)
+	}
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkutil
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigHash(t *testing.T) {
+	key := []byte("key")
+	params := map[string]string{"name": "example", "host": "example.com", "probe_filters": "region: EU"}
+
+	assert.Equal(t, ConfigHash(key, params), ConfigHash(key, map[string]string{"probe_filters": "region: EU", "name": "example", "host": "example.com"}))
+	assert.NotEqual(t, ConfigHash(key, params), ConfigHash(key, map[string]string{"name": "example", "host": "example.com", "probe_filters": "region: NA"}))
+	assert.NotEqual(t, ConfigHash(key, map[string]string{"a": "b", "c": ""}), ConfigHash(key, map[string]string{"a": "", "b": "c"}))
+	assert.NotEqual(t, ConfigHash(key, params), ConfigHash([]byte("other"), params))
+}
+
+func TestEqualIntSets(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     []int
+		b     []int
+		equal bool
+	}{
+		{
+			"both empty",
+			nil,
+			[]int{},
+			true,
+		},
+		{
+			"different order",
+			[]int{1, 2, 3},
+			[]int{3, 1, 2},
+			true,
+		},
+		{
+			"different length",
+			[]int{1, 2},
+			[]int{1, 2, 2},
+			false,
+		},
+		{
+			"different ids",
+			[]int{1, 2},
+			[]int{1, 3},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, EqualIntSets(tt.a, tt.b))
+		})
+	}
+}
+
+func TestUniqueIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		ids    [][]int
+		unique []int
+	}{
+		{
+			"no ids",
+			nil,
+			nil,
+		},
+		{
+			"keeps the order",
+			[][]int{{3, 1}, {2}},
+			[]int{3, 1, 2},
+		},
+		{
+			"duplicates within a slice",
+			[][]int{{1, 2, 1}},
+			[]int{1, 2},
+		},
+		{
+			"duplicates across slices",
+			[][]int{{1, 2}, {2, 3, 1}},
+			[]int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.unique, UniqueIDs(tt.ids...))
+		})
+	}
+}
+
+func TestEqualTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired string
+		live    []pingdom.CheckResponseTag
+		equal   bool
+	}{
+		{
+			"no tags",
+			"",
+			nil,
+			true,
+		},
+		{
+			"different order",
+			"web,api",
+			[]pingdom.CheckResponseTag{{Name: "api"}, {Name: "web"}},
+			true,
+		},
+		{
+			"missing tag",
+			"web,api",
+			[]pingdom.CheckResponseTag{{Name: "web"}},
+			false,
+		},
+		{
+			"additional tag",
+			"",
+			[]pingdom.CheckResponseTag{{Name: "web"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, EqualTags(tt.desired, tt.live))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkutil
+
+import (
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultResolution is the interval in minutes between two test runs of a check without a resolution
+const DefaultResolution = 5
+
+// Settings are the optional attributes shared by the pingdom checks of a host, i.e. tcp and ping checks
+type Settings struct {
+	Resolution               int
+	Paused                   bool
+	ResponseTimeThreshold    int
+	SendNotificationWhenDown int
+	NotifyAgainEvery         int
+	NotifyWhenBackup         bool
+	UserIds                  []int
+	TeamIds                  []int
+	IntegrationIds           []int
+	ProbeFilters             string
+	Tags                     string
+}
+
+// NewSettings returns the default settings changed by the options
+func NewSettings(opts ...Option) Settings {
+	settings := Settings{Resolution: DefaultResolution}
+
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	return settings
+}
+
+// Option configures optional attributes of a pingdom check
+type Option func(settings *Settings)
+
+// Resolution sets the interval in minutes between two test runs
+func Resolution(minutes int) Option {
+	return func(settings *Settings) {
+		settings.Resolution = minutes
+	}
+}
+
+// Paused sets whether the check is paused
+func Paused(paused bool) Option {
+	return func(settings *Settings) {
+		settings.Paused = paused
+	}
+}
+
+// ResponseTimeThreshold sets the response time in milliseconds above which the check is considered down.
+// It is ignored by tcp checks.
+func ResponseTimeThreshold(ms int) Option {
+	return func(settings *Settings) {
+		settings.ResponseTimeThreshold = ms
+	}
+}
+
+// SendNotificationWhenDown sets the number of consecutive failed test runs before an alert is sent
+func SendNotificationWhenDown(n int) Option {
+	return func(settings *Settings) {
+		settings.SendNotificationWhenDown = n
+	}
+}
+
+// NotifyAgainEvery sets the number of test runs after which an alert is repeated, 0 disables repeated alerts
+func NotifyAgainEvery(n int) Option {
+	return func(settings *Settings) {
+		settings.NotifyAgainEvery = n
+	}
+}
+
+// NotifyWhenBackup sets whether a notification is sent when the check is up again
+func NotifyWhenBackup(notify bool) Option {
+	return func(settings *Settings) {
+		settings.NotifyWhenBackup = notify
+	}
+}
+
+// UserIds sets the pingdom users receiving alerts
+func UserIds(ids []int) Option {
+	return func(settings *Settings) {
+		settings.UserIds = ids
+	}
+}
+
+// TeamIds sets the pingdom teams receiving alerts
+func TeamIds(ids []int) Option {
+	return func(settings *Settings) {
+		settings.TeamIds = ids
+	}
+}
+
+// IntegrationIds sets the pingdom integrations receiving alerts
+func IntegrationIds(ids []int) Option {
+	return func(settings *Settings) {
+		settings.IntegrationIds = ids
+	}
+}
+
+// ProbeFilters sets the filters restricting the probes running the check
+func ProbeFilters(filters string) Option {
+	return func(settings *Settings) {
+		settings.ProbeFilters = filters
+	}
+}
+
+// Tags sets the tags of the check. Duplicate tags are removed.
+func Tags(tags []string) Option {
+	return func(settings *Settings) {
+		settings.Tags = JoinTags(tags)
+	}
+}
+
+// ValidHost returns true if the host is an ip address or a dns name without scheme, port or path
+func ValidHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+
+	return len(validation.IsDNS1123Subdomain(strings.ToLower(host))) == 0
+}
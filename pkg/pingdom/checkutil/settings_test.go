@@ -0,0 +1,91 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		settings Settings
+	}{
+		{
+			"defaults",
+			nil,
+			Settings{Resolution: DefaultResolution},
+		},
+		{
+			"with options",
+			[]Option{
+				Resolution(1),
+				Paused(true),
+				ResponseTimeThreshold(500),
+				SendNotificationWhenDown(3),
+				NotifyAgainEvery(10),
+				NotifyWhenBackup(true),
+				UserIds([]int{1}),
+				TeamIds([]int{2}),
+				IntegrationIds([]int{3}),
+				ProbeFilters("region: EU"),
+				Tags([]string{"Web", "web", "db"}),
+			},
+			Settings{
+				Resolution:               1,
+				Paused:                   true,
+				ResponseTimeThreshold:    500,
+				SendNotificationWhenDown: 3,
+				NotifyAgainEvery:         10,
+				NotifyWhenBackup:         true,
+				UserIds:                  []int{1},
+				TeamIds:                  []int{2},
+				IntegrationIds:           []int{3},
+				ProbeFilters:             "region: EU",
+				Tags:                     "web,db",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.settings, NewSettings(tt.opts...))
+		})
+	}
+}
+
+func TestValidHost(t *testing.T) {
+	tests := []struct {
+		name  string
+		host  string
+		valid bool
+	}{
+		{"hostname", "DB.example.com", true},
+		{"ipv4 address", "192.0.2.1", true},
+		{"ipv6 address", "2001:db8::1", true},
+		{"scheme", "tcp://db.example.com", false},
+		{"port", "db.example.com:5432", false},
+		{"path", "example.com/health", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, ValidHost(tt.host))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkutil
+
+import (
+	"regexp"
+	"strings"
+)
+
+var invalidTagChars = regexp.MustCompile("[^a-z0-9_-]+")
+
+// SanitizeTag lower cases the tag and replaces all characters not allowed by pingdom with an underscore
+func SanitizeTag(tag string) string {
+	return invalidTagChars.ReplaceAllString(strings.ToLower(tag), "_")
+}
+
+// JoinTags sanitizes the tags, removes duplicates and joins them the way pingdom expects them
+func JoinTags(tags []string) string {
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = SanitizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+
+	return strings.Join(unique, ",")
+}
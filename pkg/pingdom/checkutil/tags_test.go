@@ -0,0 +1,57 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		s    string
+	}{
+		{
+			"no tags",
+			nil,
+			"",
+		},
+		{
+			"single tag",
+			[]string{"web"},
+			"web",
+		},
+		{
+			"duplicate tags",
+			[]string{"web", "api", "web"},
+			"web,api",
+		},
+		{
+			"invalid characters",
+			[]string{"Team A", "team_a", ""},
+			"team_a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.s, JoinTags(tt.tags))
+		})
+	}
+}
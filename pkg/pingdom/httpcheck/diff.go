@@ -0,0 +1,102 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const (
+	defaultPort          = 80
+	defaultEncryptedPort = 443
+
+	pausedStatus = "paused"
+)
+
+// Diff compares the desired check with the check read from pingdom and returns the names of all fields which differ.
+// Fields pingdom fills with defaults when they are not set, like the response time threshold, are only compared
+// if they are set on the desired check. Request headers only set in pingdom are ignored, as they cannot be removed
+// by an update.
+func Diff(desired *Check, live *pingdom.CheckResponse) []string {
+	var diff []string
+
+	add := func(field string, equal bool) {
+		if !equal {
+			diff = append(diff, field)
+		}
+	}
+
+	http := live.Type.HTTP
+	if http == nil {
+		http = &pingdom.CheckResponseHTTPDetails{}
+	}
+
+	add("name", desired.Name == live.Name)
+	add("hostname", desired.Hostname == live.Hostname)
+	add("resolution", desired.Resolution == live.Resolution)
+	add("paused", desired.Paused == (live.Paused || live.Status == pausedStatus))
+	add("sendNotificationWhenDown", desired.SendNotificationWhenDown == 0 || desired.SendNotificationWhenDown == live.SendNotificationWhenDown)
+	add("notifyAgainEvery", desired.NotifyAgainEvery == live.NotifyAgainEvery)
+	add("notifyWhenBackup", desired.NotifyWhenBackup == live.NotifyWhenBackup)
+	add("responseTimeThreshold", desired.ResponseTimeThreshold == 0 || desired.ResponseTimeThreshold == live.ResponseTimeThreshold)
+	add("userIds", checkutil.EqualIntSets(desired.UserIds, live.UserIds))
+	add("teamIds", checkutil.EqualIntSets(desired.TeamIds, live.TeamIds))
+	add("integrationIds", checkutil.EqualIntSets(desired.IntegrationIds, live.IntegrationIds))
+	add("tags", checkutil.EqualTags(desired.Tags, live.Tags))
+	add("url", normalizeURL(desired.Url) == normalizeURL(http.Url))
+	add("encryption", desired.Encryption == http.Encryption)
+	add("port", http.Port == 0 || effectivePort(&desired.HttpCheck) == http.Port)
+	add("username", desired.Username == http.Username)
+	add("password", desired.Password == http.Password)
+	add("shouldContain", desired.ShouldContain == http.ShouldContain)
+	add("shouldNotContain", desired.ShouldNotContain == http.ShouldNotContain)
+	add("postData", desired.PostData == http.PostData)
+	add("requestHeaders", equalHeaders(desired.RequestHeaders, http.RequestHeaders))
+
+	return diff
+}
+
+func normalizeURL(url string) string {
+	if url == "" {
+		return "/"
+	}
+	return url
+}
+
+// effectivePort returns the port of the check, which defaults to the port of its scheme
+func effectivePort(check *pingdom.HttpCheck) int {
+	if check.Port != 0 {
+		return check.Port
+	}
+	if check.Encryption {
+		return defaultEncryptedPort
+	}
+	return defaultPort
+}
+
+// equalHeaders returns true if all desired request headers are set in pingdom. Other headers, like the user agent
+// pingdom sets by default, are ignored.
+func equalHeaders(desired map[string]string, live map[string]string) bool {
+	for name, value := range desired {
+		if v, ok := live[name]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
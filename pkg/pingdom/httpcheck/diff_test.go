@@ -0,0 +1,166 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	desired := func(modify func(c *pingdom.HttpCheck)) *Check {
+		c := &Check{pingdom.HttpCheck{
+			Name:                     "example",
+			Hostname:                 "example.com",
+			Encryption:               true,
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			Tags:                     "pingdom-operator,web",
+		}}
+		if modify != nil {
+			modify(&c.HttpCheck)
+		}
+		return c
+	}
+
+	live := func(modify func(c *pingdom.CheckResponse)) *pingdom.CheckResponse {
+		c := &pingdom.CheckResponse{
+			ID:                       1,
+			Name:                     "example",
+			Hostname:                 "example.com",
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			ResponseTimeThreshold:    30000,
+			Status:                   "up",
+			Tags:                     []pingdom.CheckResponseTag{{Name: "web"}, {Name: "pingdom-operator"}},
+			Type: pingdom.CheckResponseType{
+				Name: "http",
+				HTTP: &pingdom.CheckResponseHTTPDetails{
+					Url:            "/",
+					Encryption:     true,
+					Port:           443,
+					RequestHeaders: map[string]string{"User-Agent": "Pingdom.com_bot_version_1.4_(http://www.pingdom.com/)"},
+				},
+			},
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		desired *Check
+		live    *pingdom.CheckResponse
+		diff    []string
+	}{
+		{
+			"no drift",
+			desired(nil),
+			live(nil),
+			nil,
+		},
+		{
+			"paused in pingdom",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Status = "paused" }),
+			[]string{"paused"},
+		},
+		{
+			"resolution changed",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Resolution = 1 }),
+			[]string{"resolution"},
+		},
+		{
+			"tag removed",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Tags = c.Tags[1:] }),
+			[]string{"tags"},
+		},
+		{
+			"recipients in different order",
+			desired(func(c *pingdom.HttpCheck) { c.UserIds = []int{1, 2} }),
+			live(func(c *pingdom.CheckResponse) { c.UserIds = []int{2, 1} }),
+			nil,
+		},
+		{
+			"custom port",
+			desired(func(c *pingdom.HttpCheck) { c.Port = 8443 }),
+			live(nil),
+			[]string{"port"},
+		},
+		{
+			"request header changed",
+			desired(func(c *pingdom.HttpCheck) { c.RequestHeaders = map[string]string{"Host": "www.example.com"} }),
+			live(func(c *pingdom.CheckResponse) { c.Type.HTTP.RequestHeaders["Host"] = "example.com" }),
+			[]string{"requestHeaders"},
+		},
+		{
+			"request header removed",
+			desired(func(c *pingdom.HttpCheck) { c.RequestHeaders = map[string]string{"Host": "www.example.com"} }),
+			live(nil),
+			[]string{"requestHeaders"},
+		},
+		{
+			"custom port cleared",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Type.HTTP.Port = 8443 }),
+			[]string{"port"},
+		},
+		{
+			"basic auth cleared",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) {
+				c.Type.HTTP.Username = "user"
+				c.Type.HTTP.Password = "pw"
+			}),
+			[]string{"username", "password"},
+		},
+		{
+			"request header cleared",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Type.HTTP.RequestHeaders["Host"] = "www.example.com" }),
+			nil,
+		},
+		{
+			"response time threshold defaulted",
+			desired(func(c *pingdom.HttpCheck) { c.ResponseTimeThreshold = 0 }),
+			live(nil),
+			nil,
+		},
+		{
+			"content match and url changed",
+			desired(func(c *pingdom.HttpCheck) {
+				c.Url = "/health"
+				c.ShouldContain = "ok"
+			}),
+			live(nil),
+			[]string{"url", "shouldContain"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, Diff(tt.desired, tt.live))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pingdom_api_requests_total",
+		Help: "Total number of requests to the pingdom checks API by method.",
+	}, []string{"method"})
+
+	apiRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pingdom_api_request_errors_total",
+		Help: "Total number of failed requests to the pingdom checks API by method and status code. Errors without a response have the status code \"unknown\".",
+	}, []string{"method", "status_code"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pingdom_api_request_duration_seconds",
+		Help:    "Latency of requests to the pingdom checks API by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestErrors, apiRequestDuration)
+}
+
+// instrumentedService records metrics for every request of the wrapped service.
+// The number of managed checks by sync state is exported by the metrics package from the check resources.
+type instrumentedService struct {
+	service Service
+}
+
+// Instrument wraps the service to export metrics about its requests to the pingdom API
+func Instrument(service Service) Service {
+	return &instrumentedService{service: service}
+}
+
+func (s *instrumentedService) List(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	defer s.observe("List", time.Now())
+
+	checks, err := s.service.List(params...)
+	return checks, s.recordError("List", err)
+}
+
+func (s *instrumentedService) Create(check pingdom.Check) (*pingdom.CheckResponse, error) {
+	defer s.observe("Create", time.Now())
+
+	created, err := s.service.Create(check)
+	return created, s.recordError("Create", err)
+}
+
+func (s *instrumentedService) Read(id int) (*pingdom.CheckResponse, error) {
+	defer s.observe("Read", time.Now())
+
+	check, err := s.service.Read(id)
+	return check, s.recordError("Read", err)
+}
+
+func (s *instrumentedService) Update(id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	defer s.observe("Update", time.Now())
+
+	res, err := s.service.Update(id, check)
+	return res, s.recordError("Update", err)
+}
+
+func (s *instrumentedService) Delete(id int) (*pingdom.PingdomResponse, error) {
+	defer s.observe("Delete", time.Now())
+
+	res, err := s.service.Delete(id)
+	return res, s.recordError("Delete", err)
+}
+
+func (s *instrumentedService) SummaryPerformance(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+	defer s.observe("SummaryPerformance", time.Now())
+
+	summary, err := s.service.SummaryPerformance(request)
+	return summary, s.recordError("SummaryPerformance", err)
+}
+
+func (s *instrumentedService) observe(method string, start time.Time) {
+	apiRequests.WithLabelValues(method).Inc()
+	apiRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// recordError counts the error, if any, and returns it unchanged
+func (s *instrumentedService) recordError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code := "unknown"
+	if c := statusCode(err); c != 0 {
+		code = strconv.Itoa(c)
+	}
+
+	apiRequestErrors.WithLabelValues(method, code).Inc()
+
+	return err
+}
+
+func statusCode(err error) int {
+	pErr, ok := err.(*pingdom.PingdomError)
+	if !ok {
+		return 0
+	}
+
+	return pErr.StatusCode
+}
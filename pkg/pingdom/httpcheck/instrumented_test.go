@@ -0,0 +1,92 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeService fails all requests for checks with an entry in errs
+type fakeService struct {
+	errs map[int]error
+}
+
+func (s *fakeService) List(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Create(check pingdom.Check) (*pingdom.CheckResponse, error) {
+	return &pingdom.CheckResponse{ID: 1}, nil
+}
+
+func (s *fakeService) Read(id int) (*pingdom.CheckResponse, error) {
+	return &pingdom.CheckResponse{ID: id}, s.errs[id]
+}
+
+func (s *fakeService) Update(id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	return &pingdom.PingdomResponse{}, s.errs[id]
+}
+
+func (s *fakeService) Delete(id int) (*pingdom.PingdomResponse, error) {
+	return &pingdom.PingdomResponse{}, s.errs[id]
+}
+
+func (s *fakeService) SummaryPerformance(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+	return &pingdom.SummaryPerformanceResponse{}, nil
+}
+
+func metricValue(t *testing.T, c prometheus.Collector) float64 {
+	m := &dto.Metric{}
+	ch := make(chan prometheus.Metric, 1)
+	c.Collect(ch)
+	assert.NoError(t, (<-ch).Write(m))
+
+	if m.Gauge != nil {
+		return m.Gauge.GetValue()
+	}
+
+	return m.Counter.GetValue()
+}
+
+func TestInstrument(t *testing.T) {
+	apiRequestErrors.Reset()
+
+	service := Instrument(&fakeService{errs: map[int]error{
+		2: &pingdom.PingdomError{StatusCode: http.StatusInternalServerError},
+		3: &pingdom.PingdomError{StatusCode: http.StatusNotFound},
+	}})
+
+	check := &pingdom.HttpCheck{Name: "test", Hostname: "example.com"}
+
+	_, _ = service.Create(check)
+	_, _ = service.Read(2)
+	_, _ = service.Read(3)
+	_, _ = service.Update(2, check)
+	_, _ = service.Update(4, check)
+	_, _ = service.Delete(3)
+
+	assert.Equal(t, 1.0, metricValue(t, apiRequestErrors.WithLabelValues("Read", "500")))
+	assert.Equal(t, 1.0, metricValue(t, apiRequestErrors.WithLabelValues("Read", "404")))
+	assert.Equal(t, 1.0, metricValue(t, apiRequestErrors.WithLabelValues("Update", "500")))
+	assert.Equal(t, 1.0, metricValue(t, apiRequestErrors.WithLabelValues("Delete", "404")))
+}
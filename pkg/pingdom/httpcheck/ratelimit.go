@@ -0,0 +1,92 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"context"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedService waits for the limiter before every request of the wrapped service
+type rateLimitedService struct {
+	service Service
+	limiter *rate.Limiter
+}
+
+// RateLimit wraps the service so all its requests share the budget of the limiter
+func RateLimit(service Service, limiter *rate.Limiter) Service {
+	return &rateLimitedService{service: service, limiter: limiter}
+}
+
+// NewLimiter returns a limiter allowing requestsPerMinute requests. A limit of zero allows all requests.
+func NewLimiter(requestsPerMinute int) *rate.Limiter {
+	if requestsPerMinute <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), 1)
+}
+
+func (s *rateLimitedService) List(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.List(params...)
+}
+
+func (s *rateLimitedService) Create(check pingdom.Check) (*pingdom.CheckResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.Create(check)
+}
+
+func (s *rateLimitedService) Read(id int) (*pingdom.CheckResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.Read(id)
+}
+
+func (s *rateLimitedService) Update(id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.Update(id, check)
+}
+
+func (s *rateLimitedService) Delete(id int) (*pingdom.PingdomResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.Delete(id)
+}
+
+func (s *rateLimitedService) SummaryPerformance(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+	if err := s.limiter.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+
+	return s.service.SummaryPerformance(request)
+}
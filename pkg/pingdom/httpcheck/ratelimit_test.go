@@ -0,0 +1,73 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimit(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 3)
+	service := RateLimit(&fakeService{}, limiter)
+
+	check, err := service.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, check.ID)
+
+	_, err = service.Update(1, &pingdom.HttpCheck{Name: "test", Hostname: "example.com"})
+	assert.NoError(t, err)
+
+	_, err = service.SummaryPerformance(pingdom.SummaryPerformanceRequest{Id: 1})
+	assert.NoError(t, err)
+
+	// all requests used the budget of the shared limiter
+	assert.False(t, limiter.Allow())
+}
+
+func TestNewLimiter(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestsPerMinute int
+		limit             rate.Limit
+	}{
+		{
+			"disabled",
+			0,
+			rate.Inf,
+		},
+		{
+			"one per second",
+			60,
+			rate.Limit(1),
+		},
+		{
+			"one every two seconds",
+			30,
+			rate.Limit(0.5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.limit, NewLimiter(tt.requestsPerMinute).Limit())
+		})
+	}
+}
@@ -28,16 +28,21 @@ var (
 )
 
 type Service interface {
+	List(params ...map[string]string) ([]pingdom.CheckResponse, error)
 	Create(check pingdom.Check) (*pingdom.CheckResponse, error)
+	Read(id int) (*pingdom.CheckResponse, error)
 	Update(id int, check pingdom.Check) (*pingdom.PingdomResponse, error)
 	Delete(id int) (*pingdom.PingdomResponse, error)
+	SummaryPerformance(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error)
 }
 
 var instance Service
 
-func InitService(client *pingdom.Client) error {
+// InitService initializes the service shared by all users of the checks API, which share a budget of
+// requestsPerMinute requests. Waiting for the budget is not included in the latencies of the requests.
+func InitService(client *pingdom.Client, requestsPerMinute int) error {
 	if instance == nil {
-		instance = client.Checks
+		instance = RateLimit(Instrument(client.Checks), NewLimiter(requestsPerMinute))
 		return nil
 	}
 
@@ -33,9 +33,146 @@ var (
 	ErrEmptyName   = errors.New("the name should not be empty string")
 	ErrNoHost      = errors.New("the url should define at least a host")
 	ErrInvalidPort = errors.New("the port is invalid")
+
+	ErrCredentialsInURL = errors.New("the url must not contain credentials when basic auth is read from a secret")
 )
 
-func SimpleHttpCheck(name string, url string) (*pingdom.HttpCheck, error) {
+// Option configures optional attributes of a pingdom http check
+type Option func(check *pingdom.HttpCheck)
+
+// Resolution sets the interval in minutes between two test runs
+func Resolution(minutes int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.Resolution = minutes
+	}
+}
+
+// Paused sets whether the check is paused
+func Paused(paused bool) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.Paused = paused
+	}
+}
+
+// ResponseTimeThreshold sets the response time in milliseconds above which the check is considered down
+func ResponseTimeThreshold(ms int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.ResponseTimeThreshold = ms
+	}
+}
+
+// SendNotificationWhenDown sets the number of consecutive failed test runs before an alert is sent
+func SendNotificationWhenDown(n int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.SendNotificationWhenDown = n
+	}
+}
+
+// NotifyAgainEvery sets the number of test runs after which an alert is repeated, 0 disables repeated alerts
+func NotifyAgainEvery(n int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.NotifyAgainEvery = n
+	}
+}
+
+// NotifyWhenBackup sets whether a notification is sent when the check is up again
+func NotifyWhenBackup(notify bool) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.NotifyWhenBackup = notify
+	}
+}
+
+// UserIds sets the pingdom users receiving alerts
+func UserIds(ids []int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.UserIds = ids
+	}
+}
+
+// TeamIds sets the pingdom teams receiving alerts
+func TeamIds(ids []int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.TeamIds = ids
+	}
+}
+
+// IntegrationIds sets the pingdom integrations receiving alerts
+func IntegrationIds(ids []int) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.IntegrationIds = ids
+	}
+}
+
+// ProbeFilters sets the filters restricting the probes running the check
+func ProbeFilters(filters string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.ProbeFilters = filters
+	}
+}
+
+// ShouldContain sets the string the response body is expected to contain
+func ShouldContain(s string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.ShouldContain = s
+	}
+}
+
+// ShouldNotContain sets the string the response body is expected not to contain
+func ShouldNotContain(s string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.ShouldNotContain = s
+	}
+}
+
+// RequestHeaders sets additional headers sent with the request
+func RequestHeaders(headers map[string]string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.RequestHeaders = headers
+	}
+}
+
+// PostData sets the body of the request, turning it into a POST request
+func PostData(data string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.PostData = data
+	}
+}
+
+// BasicAuth sets the credentials used for basic authentication, overriding the ones from the url
+func BasicAuth(username string, password string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.Username = username
+		check.Password = password
+	}
+}
+
+// Check is a pingdom http check. Unlike pingdom.HttpCheck it resets the port and the credentials
+// in pingdom when they are removed.
+type Check struct {
+	pingdom.HttpCheck
+}
+
+// PutParams returns the parameters of pingdom.HttpCheck including the default port and empty credentials
+func (c *Check) PutParams() map[string]string {
+	params := c.HttpCheck.PutParams()
+	params["port"] = strconv.Itoa(effectivePort(&c.HttpCheck))
+	if c.Username == "" {
+		params["auth"] = ""
+	}
+	return params
+}
+
+// HasCredentials returns true if the url contains credentials in its userinfo
+func HasCredentials(url string) bool {
+	parsedUrl, err := parseUrl(url)
+	if err != nil {
+		return false
+	}
+
+	return parsedUrl.User != nil
+}
+
+func SimpleHttpCheck(name string, url string, opts ...Option) (*Check, error) {
 	if name == "" {
 		return nil, ErrEmptyName
 	}
@@ -67,7 +204,7 @@ func SimpleHttpCheck(name string, url string) (*pingdom.HttpCheck, error) {
 		uri = ""
 	}
 
-	check := &pingdom.HttpCheck{
+	check := &Check{pingdom.HttpCheck{
 		Name:       name,
 		Encryption: parsedUrl.Scheme == "https",
 		Username:   parsedUrl.User.Username(),
@@ -76,6 +213,10 @@ func SimpleHttpCheck(name string, url string) (*pingdom.HttpCheck, error) {
 		Port:       port,
 		Url:        uri,
 		Resolution: 5,
+	}}
+
+	for _, opt := range opts {
+		opt(&check.HttpCheck)
 	}
 
 	err = check.Valid()
@@ -17,6 +17,7 @@ limitations under the License.
 package httpcheck
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/russellcardullo/go-pingdom/pingdom"
@@ -27,11 +28,12 @@ func TestSimpleHttpCheck(t *testing.T) {
 	type args struct {
 		name string
 		url  string
+		opts []Option
 	}
 	tests := []struct {
 		name string
 		args args
-		c    *pingdom.HttpCheck
+		c    *Check
 		err  error
 	}{
 		{
@@ -55,19 +57,19 @@ func TestSimpleHttpCheck(t *testing.T) {
 		{
 			"no scheme",
 			args{name: "example", url: "example.com"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Resolution: 5}},
 			nil,
 		},
 		{
 			"encrypted",
 			args{name: "example", url: "https://example.com"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Encryption: true, Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Encryption: true, Resolution: 5}},
 			nil,
 		},
 		{
 			"custom port",
 			args{name: "example", url: "example.com:8080"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Port: 8080, Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Port: 8080, Resolution: 5}},
 			nil,
 		},
 		{
@@ -97,43 +99,127 @@ func TestSimpleHttpCheck(t *testing.T) {
 		{
 			"with user",
 			args{name: "example", url: "user@example.com"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Resolution: 5}},
 			nil,
 		},
 		{
 			"with pw",
 			args{name: "example", url: ":pw@example.com"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Password: "pw", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Password: "pw", Resolution: 5}},
 			nil,
 		},
 		{
 			"with user:pw",
 			args{name: "example", url: "user:pw@example.com"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Password: "pw", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Password: "pw", Resolution: 5}},
 			nil,
 		},
 		{
 			"simple path",
 			args{name: "example", url: "example.com/a/path"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Url: "/a/path", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Url: "/a/path", Resolution: 5}},
 			nil,
 		},
 		{
 			"path with query",
 			args{name: "example", url: "example.com/a/path?q=uery&key=value"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "example.com", Url: "/a/path?q=uery&key=value", Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Url: "/a/path?q=uery&key=value", Resolution: 5}},
 			nil,
 		},
 		{
 			"complex url",
 			args{name: "example", url: "https://user:pw@www.example.com/a/path?q=uery&key=value"},
-			&pingdom.HttpCheck{Name: "example", Hostname: "www.example.com", Url: "/a/path?q=uery&key=value", Username: "user", Password: "pw", Encryption: true, Resolution: 5},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "www.example.com", Url: "/a/path?q=uery&key=value", Username: "user", Password: "pw", Encryption: true, Resolution: 5}},
 			nil,
 		},
+		{
+			"custom resolution",
+			args{name: "example", url: "example.com", opts: []Option{Resolution(1)}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Resolution: 1}},
+			nil,
+		},
+		{
+			"invalid resolution",
+			args{name: "example", url: "example.com", opts: []Option{Resolution(2)}},
+			nil,
+			errors.New("invalid value 2 for `Resolution`, allowed values are [1,5,15,30,60]"),
+		},
+		{
+			"paused",
+			args{name: "example", url: "example.com", opts: []Option{Paused(true)}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Paused: true, Resolution: 5}},
+			nil,
+		},
+		{
+			"response time threshold",
+			args{name: "example", url: "example.com", opts: []Option{ResponseTimeThreshold(2000)}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", ResponseTimeThreshold: 2000, Resolution: 5}},
+			nil,
+		},
+		{
+			"notifications",
+			args{name: "example", url: "example.com", opts: []Option{SendNotificationWhenDown(3), NotifyAgainEvery(10), NotifyWhenBackup(false)}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Resolution: 5, SendNotificationWhenDown: 3, NotifyAgainEvery: 10}},
+			nil,
+		},
+		{
+			"recipients",
+			args{name: "example", url: "example.com", opts: []Option{UserIds([]int{1}), TeamIds([]int{2, 3}), IntegrationIds([]int{4})}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", UserIds: []int{1}, TeamIds: []int{2, 3}, IntegrationIds: []int{4}, Resolution: 5}},
+			nil,
+		},
+		{
+			"probe filters",
+			args{name: "example", url: "example.com", opts: []Option{ProbeFilters("region: EU")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", ProbeFilters: "region: EU", Resolution: 5}},
+			nil,
+		},
+		{
+			"should contain",
+			args{name: "example", url: "example.com", opts: []Option{ShouldContain("ok")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", ShouldContain: "ok", Resolution: 5}},
+			nil,
+		},
+		{
+			"should not contain",
+			args{name: "example", url: "example.com", opts: []Option{ShouldNotContain("degraded")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", ShouldNotContain: "degraded", Resolution: 5}},
+			nil,
+		},
+		{
+			"request headers",
+			args{name: "example", url: "example.com", opts: []Option{RequestHeaders(map[string]string{"Host": "www.example.com"})}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", RequestHeaders: map[string]string{"Host": "www.example.com"}, Resolution: 5}},
+			nil,
+		},
+		{
+			"post data",
+			args{name: "example", url: "example.com", opts: []Option{PostData("key=value")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", PostData: "key=value", Resolution: 5}},
+			nil,
+		},
+		{
+			"basic auth",
+			args{name: "example", url: "example.com", opts: []Option{BasicAuth("user", "pw")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Password: "pw", Resolution: 5}},
+			nil,
+		},
+		{
+			"basic auth overrides url credentials",
+			args{name: "example", url: "foo:bar@example.com", opts: []Option{BasicAuth("user", "pw")}},
+			&Check{pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Password: "pw", Resolution: 5}},
+			nil,
+		},
+		{
+			"should contain and should not contain",
+			args{name: "example", url: "example.com", opts: []Option{ShouldContain("ok"), ShouldNotContain("degraded")}},
+			nil,
+			errors.New("`ShouldContain` and `ShouldNotContain` must not be declared at the same time"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, err := SimpleHttpCheck(tt.args.name, tt.args.url)
+			c, err := SimpleHttpCheck(tt.args.name, tt.args.url, tt.args.opts...)
 
 			if err != nil && tt.err != nil {
 				assert.Equal(t, tt.err.Error(), err.Error())
@@ -142,3 +228,59 @@ func TestSimpleHttpCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestCheck_PutParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		check  pingdom.HttpCheck
+		params map[string]string
+	}{
+		{
+			"default port",
+			pingdom.HttpCheck{Name: "example", Hostname: "example.com"},
+			map[string]string{"port": "80", "auth": ""},
+		},
+		{
+			"default encrypted port",
+			pingdom.HttpCheck{Name: "example", Hostname: "example.com", Encryption: true},
+			map[string]string{"port": "443", "auth": ""},
+		},
+		{
+			"custom port",
+			pingdom.HttpCheck{Name: "example", Hostname: "example.com", Port: 8080},
+			map[string]string{"port": "8080", "auth": ""},
+		},
+		{
+			"credentials",
+			pingdom.HttpCheck{Name: "example", Hostname: "example.com", Username: "user", Password: "pw"},
+			map[string]string{"port": "80", "auth": "user:pw"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := (&Check{tt.check}).PutParams()
+			for key, value := range tt.params {
+				v, ok := params[key]
+				assert.True(t, ok, key)
+				assert.Equal(t, value, v, key)
+			}
+		})
+	}
+}
+
+func TestHasCredentials(t *testing.T) {
+	tests := []struct {
+		url         string
+		credentials bool
+	}{
+		{"example.com", false},
+		{"https://example.com/health", false},
+		{"foo:bar@example.com", true},
+		{"https://foo@example.com", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.credentials, HasCredentials(tt.url))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"strings"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const (
+	// ManagedTag is added to every check managed by the operator
+	ManagedTag = "pingdom-operator"
+
+	clusterTagPrefix   = "cluster-"
+	kindTagPrefix      = "kind-"
+	namespaceTagPrefix = "namespace-"
+	nameTagPrefix      = "name-"
+	uidTagPrefix       = "uid-"
+)
+
+// ManagedTags returns the tags identifying the kubernetes object a check belongs to.
+// The cluster tag is omitted if no cluster name is given.
+func ManagedTags(cluster string, kind string, namespace string, name string, uid string) []string {
+	return append(IdentityTags(cluster, kind, namespace, name), UIDTag(uid))
+}
+
+// IdentityTags returns the tags identifying the kubernetes object a check belongs to, independent of its uid.
+// They stay the same if an object is deleted and created again.
+func IdentityTags(cluster string, kind string, namespace string, name string) []string {
+	tags := []string{ManagedTag}
+
+	if cluster != "" {
+		tags = append(tags, ClusterTag(cluster))
+	}
+
+	return append(tags, KindTag(kind), NamespaceTag(namespace), NameTag(name))
+}
+
+// ClusterTag returns the tag identifying checks of the given cluster
+func ClusterTag(cluster string) string {
+	return checkutil.SanitizeTag(clusterTagPrefix + cluster)
+}
+
+// KindTag returns the tag identifying checks of objects of the given kind
+func KindTag(kind string) string {
+	return checkutil.SanitizeTag(kindTagPrefix + kind)
+}
+
+// NamespaceTag returns the tag identifying checks of the given namespace
+func NamespaceTag(namespace string) string {
+	return checkutil.SanitizeTag(namespaceTagPrefix + namespace)
+}
+
+// NameTag returns the tag identifying checks of objects with the given name
+func NameTag(name string) string {
+	return checkutil.SanitizeTag(nameTagPrefix + name)
+}
+
+// UIDTag returns the tag identifying the check of the object with the given uid
+func UIDTag(uid string) string {
+	return checkutil.SanitizeTag(uidTagPrefix + uid)
+}
+
+// HasTags returns true if the check has all of the given tags
+func HasTags(check *pingdom.CheckResponse, tags ...string) bool {
+	for _, tag := range tags {
+		if findTag(check, func(t string) bool { return t == tag }) == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClusterTagOf returns the cluster tag of the check or an empty string if it has none
+func ClusterTagOf(check *pingdom.CheckResponse) string {
+	return findTag(check, func(t string) bool { return strings.HasPrefix(t, clusterTagPrefix) })
+}
+
+// OwnerUID returns the uid of the kubernetes object owning the check or an empty string if it has no owner
+func OwnerUID(check *pingdom.CheckResponse) string {
+	tag := findTag(check, func(t string) bool { return strings.HasPrefix(t, uidTagPrefix) })
+	return strings.TrimPrefix(tag, uidTagPrefix)
+}
+
+func findTag(check *pingdom.CheckResponse, match func(tag string) bool) string {
+	for _, tag := range check.Tags {
+		if match(tag.Name) {
+			return tag.Name
+		}
+	}
+
+	return ""
+}
+
+// Tags sets the tags of the check. Duplicate tags are removed.
+func Tags(tags []string) Option {
+	return func(check *pingdom.HttpCheck) {
+		check.Tags = checkutil.JoinTags(tags)
+	}
+}
+
+// ListByTags returns all checks having at least one of the given tags. The tags of the checks are included.
+func ListByTags(service Service, tags ...string) ([]pingdom.CheckResponse, error) {
+	return service.List(map[string]string{
+		"tags":         strings.Join(tags, ","),
+		"include_tags": "true",
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpcheck
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		cluster   string
+		namespace string
+		object    string
+		uid       string
+		tags      []string
+	}{
+		{
+			"without cluster",
+			"",
+			"default",
+			"example",
+			"0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69",
+			[]string{"pingdom-operator", "kind-httpcheck", "namespace-default", "name-example", "uid-0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69"},
+		},
+		{
+			"with cluster",
+			"prod",
+			"default",
+			"example",
+			"0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69",
+			[]string{"pingdom-operator", "cluster-prod", "kind-httpcheck", "namespace-default", "name-example", "uid-0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69"},
+		},
+		{
+			"sanitized",
+			"Prod.EU",
+			"default",
+			"www.example.com",
+			"0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69",
+			[]string{"pingdom-operator", "cluster-prod_eu", "kind-httpcheck", "namespace-default", "name-www_example_com", "uid-0b7c5a1e-6c5d-4a3b-9f3e-1f2d3c4b5a69"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.tags, ManagedTags(tt.cluster, "HttpCheck", tt.namespace, tt.object, tt.uid))
+		})
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	tags := func(names ...string) []pingdom.CheckResponseTag {
+		var tags []pingdom.CheckResponseTag
+		for _, name := range names {
+			tags = append(tags, pingdom.CheckResponseTag{Name: name, Type: "u"})
+		}
+		return tags
+	}
+
+	tests := []struct {
+		name     string
+		check    *pingdom.CheckResponse
+		identity bool
+		cluster  string
+		uid      string
+	}{
+		{
+			"unmanaged check",
+			&pingdom.CheckResponse{Tags: tags("web")},
+			false,
+			"",
+			"",
+		},
+		{
+			"managed check",
+			&pingdom.CheckResponse{Tags: tags("pingdom-operator", "kind-httpcheck", "namespace-default", "name-example", "uid-1234")},
+			true,
+			"",
+			"1234",
+		},
+		{
+			"managed check in cluster",
+			&pingdom.CheckResponse{Tags: tags("pingdom-operator", "cluster-prod", "kind-httpcheck", "namespace-default", "name-example", "uid-1234")},
+			true,
+			"cluster-prod",
+			"1234",
+		},
+		{
+			"managed check of other object",
+			&pingdom.CheckResponse{Tags: tags("pingdom-operator", "kind-httpcheck", "namespace-default", "name-other", "uid-5678")},
+			false,
+			"",
+			"5678",
+		},
+		{
+			"managed check of other kind",
+			&pingdom.CheckResponse{Tags: tags("pingdom-operator", "kind-tcpcheck", "namespace-default", "name-example", "uid-5678")},
+			false,
+			"",
+			"5678",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.identity, HasTags(tt.check, IdentityTags("", "HttpCheck", "default", "example")...))
+			assert.Equal(t, tt.cluster, ClusterTagOf(tt.check))
+			assert.Equal(t, tt.uid, OwnerUID(tt.check))
+		})
+	}
+}
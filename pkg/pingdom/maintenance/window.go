@@ -0,0 +1,145 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const (
+	// RecurrenceNone is the recurrence type of windows which do not repeat
+	RecurrenceNone = "none"
+)
+
+var (
+	ErrEmptyDescription = errors.New("the description should not be empty string")
+	ErrInvalidPeriod    = errors.New("the end should be after the start")
+	ErrNoChecks         = errors.New("the maintenance window should contain at least one check")
+)
+
+// Option configures optional attributes of a pingdom maintenance window
+type Option func(window *pingdom.MaintenanceWindow)
+
+// Recurrence repeats the window every n days, weeks or months until the given time.
+// A zero until sends no end, which leaves the end to pingdom: new recurrences get the default end of pingdom
+// and existing recurrences keep their end, since pingdom offers no way to remove it.
+func Recurrence(recurrenceType string, every int, until time.Time) Option {
+	return func(window *pingdom.MaintenanceWindow) {
+		window.RecurrenceType = recurrenceType
+		window.RepeatEvery = every
+		if !until.IsZero() {
+			window.EffectiveTo = int(until.Unix())
+		}
+	}
+}
+
+// SimpleMaintenanceWindow returns a window pausing the checks with the given ids from start to end.
+// Windows without recurrence explicitly set the recurrence type none, so pingdom removes a previous recurrence.
+func SimpleMaintenanceWindow(description string, start time.Time, end time.Time, checkIDs []int, opts ...Option) (*pingdom.MaintenanceWindow, error) {
+	if description == "" {
+		return nil, ErrEmptyDescription
+	}
+
+	if !end.After(start) {
+		return nil, ErrInvalidPeriod
+	}
+
+	if len(checkIDs) == 0 {
+		return nil, ErrNoChecks
+	}
+
+	window := &pingdom.MaintenanceWindow{
+		Description: description,
+		From:        start.Unix(),
+		To:          end.Unix(),
+		UptimeIDs:   joinIDs(checkIDs),
+
+		RecurrenceType: RecurrenceNone,
+	}
+
+	for _, opt := range opts {
+		opt(window)
+	}
+
+	err := window.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// Diff compares the desired window with the window read from pingdom and returns the names of all fields which differ.
+// The interval of the recurrence is only compared for recurring windows and the end only if the desired window sets one.
+func Diff(desired *pingdom.MaintenanceWindow, live *pingdom.MaintenanceResponse) []string {
+	var diff []string
+
+	recurring := normalizeRecurrence(desired.RecurrenceType) != RecurrenceNone
+
+	add := func(field string, equal bool) {
+		if !equal {
+			diff = append(diff, field)
+		}
+	}
+
+	add("description", desired.Description == live.Description)
+	add("from", desired.From == live.From)
+	add("to", desired.To == live.To)
+	add("recurrenceType", normalizeRecurrence(desired.RecurrenceType) == normalizeRecurrence(live.RecurrenceType))
+	add("repeatEvery", !recurring || desired.RepeatEvery == live.RepeatEvery)
+	add("effectiveTo", !recurring || desired.EffectiveTo == 0 || int64(desired.EffectiveTo) == live.EffectiveTo)
+	add("uptimeIds", checkutil.EqualIntSets(splitIDs(desired.UptimeIDs), live.Checks.Uptime))
+
+	return diff
+}
+
+func normalizeRecurrence(recurrenceType string) string {
+	if recurrenceType == "" {
+		return RecurrenceNone
+	}
+	return recurrenceType
+}
+
+func joinIDs(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+
+	s := make([]string, 0, len(sorted))
+	for _, id := range sorted {
+		s = append(s, strconv.Itoa(id))
+	}
+
+	return strings.Join(s, ",")
+}
+
+func splitIDs(s string) []int {
+	var ids []int
+	for _, id := range strings.Split(s, ",") {
+		if i, err := strconv.Atoi(id); err == nil {
+			ids = append(ids, i)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	start = time.Date(2019, 5, 1, 22, 0, 0, 0, time.UTC)
+	end   = start.Add(2 * time.Hour)
+)
+
+func TestSimpleMaintenanceWindow(t *testing.T) {
+	type args struct {
+		description string
+		start       time.Time
+		end         time.Time
+		checkIDs    []int
+		opts        []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		w    *pingdom.MaintenanceWindow
+		err  error
+	}{
+		{
+			"empty description",
+			args{description: "", start: start, end: end, checkIDs: []int{1}},
+			nil,
+			ErrEmptyDescription,
+		},
+		{
+			"end before start",
+			args{description: "deploy", start: end, end: start, checkIDs: []int{1}},
+			nil,
+			ErrInvalidPeriod,
+		},
+		{
+			"no checks",
+			args{description: "deploy", start: start, end: end},
+			nil,
+			ErrNoChecks,
+		},
+		{
+			"single window",
+			args{description: "deploy", start: start, end: end, checkIDs: []int{3, 1, 2}},
+			&pingdom.MaintenanceWindow{Description: "deploy", From: 1556748000, To: 1556755200, UptimeIDs: "1,2,3", RecurrenceType: "none"},
+			nil,
+		},
+		{
+			"recurring window",
+			args{description: "deploy", start: start, end: end, checkIDs: []int{1}, opts: []Option{
+				Recurrence("week", 2, start.Add(30*24*time.Hour)),
+			}},
+			&pingdom.MaintenanceWindow{
+				Description:    "deploy",
+				From:           1556748000,
+				To:             1556755200,
+				UptimeIDs:      "1",
+				RecurrenceType: "week",
+				RepeatEvery:    2,
+				EffectiveTo:    1559340000,
+			},
+			nil,
+		},
+		{
+			"recurring window without end",
+			args{description: "deploy", start: start, end: end, checkIDs: []int{1}, opts: []Option{
+				Recurrence("day", 1, time.Time{}),
+			}},
+			&pingdom.MaintenanceWindow{
+				Description:    "deploy",
+				From:           1556748000,
+				To:             1556755200,
+				UptimeIDs:      "1",
+				RecurrenceType: "day",
+				RepeatEvery:    1,
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := SimpleMaintenanceWindow(tt.args.description, tt.args.start, tt.args.end, tt.args.checkIDs, tt.args.opts...)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.w, w)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	desired := &pingdom.MaintenanceWindow{Description: "deploy", From: 1556748000, To: 1556755200, UptimeIDs: "1,2", RecurrenceType: "none"}
+	recurring := &pingdom.MaintenanceWindow{Description: "deploy", From: 1556748000, To: 1556755200, UptimeIDs: "1,2", RecurrenceType: "week", RepeatEvery: 1, EffectiveTo: 1559340000}
+
+	unbounded := &pingdom.MaintenanceWindow{Description: "deploy", From: 1556748000, To: 1556755200, UptimeIDs: "1,2", RecurrenceType: "week", RepeatEvery: 1}
+
+	live := func(modify func(w *pingdom.MaintenanceResponse)) *pingdom.MaintenanceResponse {
+		w := &pingdom.MaintenanceResponse{
+			ID:             1,
+			Description:    "deploy",
+			From:           1556748000,
+			To:             1556755200,
+			RecurrenceType: "none",
+			Checks:         pingdom.MaintenanceCheckResponse{Uptime: []int{2, 1}},
+		}
+		if modify != nil {
+			modify(w)
+		}
+		return w
+	}
+
+	tests := []struct {
+		name    string
+		desired *pingdom.MaintenanceWindow
+		live    *pingdom.MaintenanceResponse
+		diff    []string
+	}{
+		{
+			"no changes",
+			desired,
+			live(nil),
+			nil,
+		},
+		{
+			"check removed",
+			desired,
+			live(func(w *pingdom.MaintenanceResponse) { w.Checks.Uptime = []int{1} }),
+			[]string{"uptimeIds"},
+		},
+		{
+			"moved",
+			desired,
+			live(func(w *pingdom.MaintenanceResponse) { w.From, w.To = w.From+3600, w.To+3600 }),
+			[]string{"from", "to"},
+		},
+		{
+			"recurring",
+			desired,
+			live(func(w *pingdom.MaintenanceResponse) { w.RecurrenceType = "day" }),
+			[]string{"recurrenceType"},
+		},
+		{
+			"recurrence removed",
+			desired,
+			live(func(w *pingdom.MaintenanceResponse) {
+				w.RecurrenceType, w.RepeatEvery, w.EffectiveTo = "week", 1, 1559340000
+			}),
+			[]string{"recurrenceType"},
+		},
+		{
+			"recurrence end changed",
+			recurring,
+			live(func(w *pingdom.MaintenanceResponse) {
+				w.RecurrenceType, w.RepeatEvery, w.EffectiveTo = "week", 1, 1561932000
+			}),
+			[]string{"effectiveTo"},
+		},
+		{
+			"recurrence end left to pingdom",
+			unbounded,
+			live(func(w *pingdom.MaintenanceResponse) {
+				w.RecurrenceType, w.RepeatEvery, w.EffectiveTo = "week", 1, 1559340000
+			}),
+			nil,
+		},
+		{
+			"recurrence unchanged",
+			recurring,
+			live(func(w *pingdom.MaintenanceResponse) {
+				w.RecurrenceType, w.RepeatEvery, w.EffectiveTo = "week", 1, 1559340000
+			}),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, Diff(tt.desired, tt.live))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingcheck
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	desired := func(modify func(c *Check)) *Check {
+		c := &Check{pingdom.PingCheck{
+			Name:                     "example",
+			Hostname:                 "router.example.com",
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			Tags:                     "pingdom-operator,network",
+		}}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	live := func(modify func(c *pingdom.CheckResponse)) *pingdom.CheckResponse {
+		c := &pingdom.CheckResponse{
+			ID:                       1,
+			Name:                     "example",
+			Hostname:                 "router.example.com",
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			ResponseTimeThreshold:    30000,
+			Status:                   "up",
+			Tags:                     []pingdom.CheckResponseTag{{Name: "network"}, {Name: "pingdom-operator"}},
+			Type:                     pingdom.CheckResponseType{Name: "ping"},
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		desired *Check
+		live    *pingdom.CheckResponse
+		diff    []string
+	}{
+		{
+			"no drift",
+			desired(nil),
+			live(nil),
+			nil,
+		},
+		{
+			"paused in pingdom",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Status = "paused" }),
+			[]string{"paused"},
+		},
+		{
+			"response time threshold changed",
+			desired(func(c *Check) { c.ResponseTimeThreshold = 500 }),
+			live(nil),
+			[]string{"responseTimeThreshold"},
+		},
+		{
+			"hostname changed",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Hostname = "192.0.2.1" }),
+			[]string{"hostname"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, Diff(tt.desired, tt.live))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingcheck
+
+import (
+	"errors"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var (
+	ErrEmptyName   = errors.New("the name should not be empty string")
+	ErrEmptyHost   = errors.New("the host should not be empty string")
+	ErrInvalidHost = errors.New("the host should be a hostname or an ip address")
+)
+
+// Check is a pingdom ping check. Unlike pingdom.PingCheck it sends its tags to pingdom.
+type Check struct {
+	pingdom.PingCheck
+}
+
+// PutParams returns the parameters of pingdom.PingCheck including the tags
+func (c *Check) PutParams() map[string]string {
+	params := c.PingCheck.PutParams()
+	params["tags"] = c.Tags
+	return params
+}
+
+// PostParams returns the parameters of pingdom.PingCheck including the tags
+func (c *Check) PostParams() map[string]string {
+	params := c.PingCheck.PostParams()
+	if c.Tags != "" {
+		params["tags"] = c.Tags
+	}
+	return params
+}
+
+// SimplePingCheck returns a ping check of the host configured by the options
+func SimplePingCheck(name string, host string, opts ...checkutil.Option) (*Check, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	if host == "" {
+		return nil, ErrEmptyHost
+	}
+
+	if !checkutil.ValidHost(host) {
+		return nil, ErrInvalidHost
+	}
+
+	settings := checkutil.NewSettings(opts...)
+
+	check := &Check{pingdom.PingCheck{
+		Name:                     name,
+		Hostname:                 host,
+		Resolution:               settings.Resolution,
+		Paused:                   settings.Paused,
+		ResponseTimeThreshold:    settings.ResponseTimeThreshold,
+		SendNotificationWhenDown: settings.SendNotificationWhenDown,
+		NotifyAgainEvery:         settings.NotifyAgainEvery,
+		NotifyWhenBackup:         settings.NotifyWhenBackup,
+		UserIds:                  settings.UserIds,
+		TeamIds:                  settings.TeamIds,
+		IntegrationIds:           settings.IntegrationIds,
+		ProbeFilters:             settings.ProbeFilters,
+		Tags:                     settings.Tags,
+	}}
+
+	err := check.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
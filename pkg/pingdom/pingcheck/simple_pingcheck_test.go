@@ -0,0 +1,105 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingcheck
+
+import (
+	"testing"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplePingCheck(t *testing.T) {
+	type args struct {
+		name string
+		host string
+		opts []checkutil.Option
+	}
+	tests := []struct {
+		name string
+		args args
+		c    *Check
+		err  error
+	}{
+		{
+			"empty name",
+			args{name: "", host: "router.example.com"},
+			nil,
+			ErrEmptyName,
+		},
+		{
+			"empty host",
+			args{name: "example", host: ""},
+			nil,
+			ErrEmptyHost,
+		},
+		{
+			"host with scheme",
+			args{name: "example", host: "http://router.example.com"},
+			nil,
+			ErrInvalidHost,
+		},
+		{
+			"hostname",
+			args{name: "example", host: "router.example.com"},
+			&Check{pingdom.PingCheck{Name: "example", Hostname: "router.example.com", Resolution: 5}},
+			nil,
+		},
+		{
+			"ip address",
+			args{name: "example", host: "192.0.2.1"},
+			&Check{pingdom.PingCheck{Name: "example", Hostname: "192.0.2.1", Resolution: 5}},
+			nil,
+		},
+		{
+			"with options",
+			args{name: "example", host: "192.0.2.1", opts: []checkutil.Option{
+				checkutil.Resolution(1),
+				checkutil.ResponseTimeThreshold(500),
+				checkutil.Tags([]string{"Network", "network"}),
+			}},
+			&Check{pingdom.PingCheck{
+				Name:                  "example",
+				Hostname:              "192.0.2.1",
+				Resolution:            1,
+				ResponseTimeThreshold: 500,
+				Tags:                  "network",
+			}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := SimplePingCheck(tt.args.name, tt.args.host, tt.args.opts...)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.c, c)
+		})
+	}
+}
+
+func TestCheckParams(t *testing.T) {
+	check := &Check{pingdom.PingCheck{Name: "example", Hostname: "192.0.2.1", Resolution: 5, Tags: "pingdom-operator,network"}}
+
+	assert.Equal(t, "pingdom-operator,network", check.PutParams()["tags"])
+	assert.Equal(t, "pingdom-operator,network", check.PostParams()["tags"])
+	assert.Equal(t, "ping", check.PostParams()["type"])
+
+	check.Tags = ""
+	assert.Equal(t, "", check.PutParams()["tags"])
+	assert.NotContains(t, check.PostParams(), "tags")
+}
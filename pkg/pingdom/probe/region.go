@@ -0,0 +1,63 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InvalidRegionError is returned when no pingdom probe exists in the given region
+type InvalidRegionError struct {
+	Region  string
+	Regions []string
+}
+
+func (e *InvalidRegionError) Error() string {
+	return fmt.Sprintf("the probe region %q is invalid, allowed values are %v", e.Region, e.Regions)
+}
+
+// ValidateRegion checks that at least one active pingdom probe exists in the given region
+func ValidateRegion(service Service, region string) error {
+	probes, err := service.List()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var regions []string
+	for _, p := range probes {
+		if !p.Active {
+			continue
+		}
+		if p.Region == region {
+			return nil
+		}
+		if !seen[p.Region] {
+			seen[p.Region] = true
+			regions = append(regions, p.Region)
+		}
+	}
+
+	sort.Strings(regions)
+	return &InvalidRegionError{Region: region, Regions: regions}
+}
+
+// RegionFilter returns the probe filter limiting a check to the given region
+func RegionFilter(region string) string {
+	return fmt.Sprintf("region: %s", region)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	probes []pingdom.ProbeResponse
+}
+
+func (s *fakeService) List(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+	return s.probes, nil
+}
+
+func TestValidateRegion(t *testing.T) {
+	service := &fakeService{probes: []pingdom.ProbeResponse{
+		{ID: 1, Region: "NA", Active: true},
+		{ID: 2, Region: "EU", Active: true},
+		{ID: 3, Region: "EU", Active: true},
+		{ID: 4, Region: "APAC", Active: false},
+	}}
+
+	tests := []struct {
+		name   string
+		region string
+		err    error
+	}{
+		{
+			"valid region",
+			"EU",
+			nil,
+		},
+		{
+			"inactive region",
+			"APAC",
+			&InvalidRegionError{Region: "APAC", Regions: []string{"EU", "NA"}},
+		},
+		{
+			"unknown region",
+			"MARS",
+			&InvalidRegionError{Region: "MARS", Regions: []string{"EU", "NA"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, ValidateRegion(service, tt.region))
+		})
+	}
+}
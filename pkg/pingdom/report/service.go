@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"errors"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var (
+	ErrAlreadyInitialized = errors.New("the public report service has already been initialized")
+	ErrNotInitialized     = errors.New("the public report service has not been initialized")
+)
+
+type Service interface {
+	List() ([]pingdom.PublicReportResponse, error)
+	PublishCheck(id int) (*pingdom.PingdomResponse, error)
+	WithdrawlCheck(id int) (*pingdom.PingdomResponse, error)
+}
+
+var instance Service
+
+func InitService(client *pingdom.Client) error {
+	if instance == nil {
+		instance = client.PublicReport
+		return nil
+	}
+
+	return ErrAlreadyInitialized
+}
+
+func ServiceInstance() (Service, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	return nil, ErrNotInitialized
+}
+
+// Published returns true if the check with the given id is included in the public report
+func Published(service Service, id int) (bool, error) {
+	reports, err := service.List()
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range reports {
+		if r.ID == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
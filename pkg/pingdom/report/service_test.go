@@ -0,0 +1,84 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	reports []pingdom.PublicReportResponse
+	err     error
+}
+
+func (s *fakeService) List() ([]pingdom.PublicReportResponse, error) {
+	return s.reports, s.err
+}
+
+func (s *fakeService) PublishCheck(id int) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) WithdrawlCheck(id int) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func TestPublished(t *testing.T) {
+	listErr := &pingdom.PingdomError{StatusCode: 500, Message: "internal error"}
+
+	tests := []struct {
+		name      string
+		service   *fakeService
+		published bool
+		err       error
+	}{
+		{
+			"no reports",
+			&fakeService{},
+			false,
+			nil,
+		},
+		{
+			"published",
+			&fakeService{reports: []pingdom.PublicReportResponse{{ID: 1}, {ID: 2}}},
+			true,
+			nil,
+		},
+		{
+			"not published",
+			&fakeService{reports: []pingdom.PublicReportResponse{{ID: 1}}},
+			false,
+			nil,
+		},
+		{
+			"list error",
+			&fakeService{err: listErr},
+			false,
+			listErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published, err := Published(tt.service, 2)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.published, published)
+		})
+	}
+}
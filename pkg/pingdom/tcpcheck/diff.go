@@ -0,0 +1,58 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpcheck
+
+import (
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const pausedStatus = "paused"
+
+// Diff compares the desired check with the check read from pingdom and returns the names of all fields which differ.
+// Fields pingdom fills with defaults when they are not set are only compared if they are set on the desired check.
+func Diff(desired *Check, live *pingdom.CheckResponse) []string {
+	var diff []string
+
+	add := func(field string, equal bool) {
+		if !equal {
+			diff = append(diff, field)
+		}
+	}
+
+	tcp := live.Type.TCP
+	if tcp == nil {
+		tcp = &pingdom.CheckResponseTCPDetails{}
+	}
+
+	add("name", desired.Name == live.Name)
+	add("hostname", desired.Hostname == live.Hostname)
+	add("resolution", desired.Resolution == live.Resolution)
+	add("paused", desired.Paused == (live.Paused || live.Status == pausedStatus))
+	add("sendNotificationWhenDown", desired.SendNotificationWhenDown == 0 || desired.SendNotificationWhenDown == live.SendNotificationWhenDown)
+	add("notifyAgainEvery", desired.NotifyAgainEvery == live.NotifyAgainEvery)
+	add("notifyWhenBackup", desired.NotifyWhenBackup == live.NotifyWhenBackup)
+	add("userIds", checkutil.EqualIntSets(desired.UserIds, live.UserIds))
+	add("teamIds", checkutil.EqualIntSets(desired.TeamIds, live.TeamIds))
+	add("integrationIds", checkutil.EqualIntSets(desired.IntegrationIds, live.IntegrationIds))
+	add("tags", checkutil.EqualTags(desired.Tags, live.Tags))
+	add("port", desired.Port == tcp.Port)
+	add("stringToSend", desired.StringToSend == tcp.StringToSend)
+	add("stringToExpect", desired.StringToExpect == tcp.StringToExpect)
+
+	return diff
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpcheck
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	desired := func(modify func(c *pingdom.TCPCheck)) *Check {
+		c := &Check{pingdom.TCPCheck{
+			Name:                     "example",
+			Hostname:                 "db.example.com",
+			Port:                     5432,
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			Tags:                     "pingdom-operator,db",
+		}}
+		if modify != nil {
+			modify(&c.TCPCheck)
+		}
+		return c
+	}
+
+	live := func(modify func(c *pingdom.CheckResponse)) *pingdom.CheckResponse {
+		c := &pingdom.CheckResponse{
+			ID:                       1,
+			Name:                     "example",
+			Hostname:                 "db.example.com",
+			Resolution:               5,
+			SendNotificationWhenDown: 2,
+			NotifyWhenBackup:         true,
+			Status:                   "up",
+			Tags:                     []pingdom.CheckResponseTag{{Name: "db"}, {Name: "pingdom-operator"}},
+			Type: pingdom.CheckResponseType{
+				Name: "tcp",
+				TCP:  &pingdom.CheckResponseTCPDetails{Port: 5432},
+			},
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		desired *Check
+		live    *pingdom.CheckResponse
+		diff    []string
+	}{
+		{
+			"no drift",
+			desired(nil),
+			live(nil),
+			nil,
+		},
+		{
+			"paused in pingdom",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Status = "paused" }),
+			[]string{"paused"},
+		},
+		{
+			"port changed",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Type.TCP.Port = 5433 }),
+			[]string{"port"},
+		},
+		{
+			"expected string removed",
+			desired(func(c *pingdom.TCPCheck) { c.StringToExpect = "ok" }),
+			live(nil),
+			[]string{"stringToExpect"},
+		},
+		{
+			"strings cleared",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) {
+				c.Type.TCP.StringToSend = "PING"
+				c.Type.TCP.StringToExpect = "PONG"
+			}),
+			[]string{"stringToSend", "stringToExpect"},
+		},
+		{
+			"not a tcp check",
+			desired(nil),
+			live(func(c *pingdom.CheckResponse) { c.Type = pingdom.CheckResponseType{Name: "http"} }),
+			[]string{"port"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, Diff(tt.desired, tt.live))
+		})
+	}
+}
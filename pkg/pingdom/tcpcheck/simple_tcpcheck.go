@@ -0,0 +1,91 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpcheck
+
+import (
+	"errors"
+	"math"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var (
+	ErrEmptyName   = errors.New("the name should not be empty string")
+	ErrEmptyHost   = errors.New("the host should not be empty string")
+	ErrInvalidHost = errors.New("the host should be a hostname or an ip address")
+	ErrInvalidPort = errors.New("the port is invalid")
+)
+
+// Check is a pingdom tcp check. Unlike pingdom.TCPCheck it clears the strings to send and expect
+// in pingdom when they are removed.
+type Check struct {
+	pingdom.TCPCheck
+}
+
+// PutParams returns the parameters of pingdom.TCPCheck including empty strings to send and expect
+func (c *Check) PutParams() map[string]string {
+	params := c.TCPCheck.PutParams()
+	params["stringtosend"] = c.StringToSend
+	params["stringtoexpect"] = c.StringToExpect
+	return params
+}
+
+// SimpleTCPCheck returns a tcp check of the port of the host configured by the options.
+// The strings to send and expect are set on the returned check.
+func SimpleTCPCheck(name string, host string, port int, opts ...checkutil.Option) (*Check, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	if host == "" {
+		return nil, ErrEmptyHost
+	}
+
+	if !checkutil.ValidHost(host) {
+		return nil, ErrInvalidHost
+	}
+
+	if port <= 0 || port > math.MaxUint16 {
+		return nil, ErrInvalidPort
+	}
+
+	settings := checkutil.NewSettings(opts...)
+
+	check := &Check{pingdom.TCPCheck{
+		Name:                     name,
+		Hostname:                 host,
+		Port:                     port,
+		Resolution:               settings.Resolution,
+		Paused:                   settings.Paused,
+		SendNotificationWhenDown: settings.SendNotificationWhenDown,
+		NotifyAgainEvery:         settings.NotifyAgainEvery,
+		NotifyWhenBackup:         settings.NotifyWhenBackup,
+		UserIds:                  settings.UserIds,
+		TeamIds:                  settings.TeamIds,
+		IntegrationIds:           settings.IntegrationIds,
+		ProbeFilters:             settings.ProbeFilters,
+		Tags:                     settings.Tags,
+	}}
+
+	err := check.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
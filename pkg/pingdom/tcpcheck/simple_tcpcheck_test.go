@@ -0,0 +1,148 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpcheck
+
+import (
+	"testing"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleTCPCheck(t *testing.T) {
+	type args struct {
+		name string
+		host string
+		port int
+		opts []checkutil.Option
+	}
+	tests := []struct {
+		name string
+		args args
+		c    *Check
+		err  error
+	}{
+		{
+			"empty name",
+			args{name: "", host: "db.example.com", port: 5432},
+			nil,
+			ErrEmptyName,
+		},
+		{
+			"empty host",
+			args{name: "example", host: "", port: 5432},
+			nil,
+			ErrEmptyHost,
+		},
+		{
+			"host with scheme",
+			args{name: "example", host: "tcp://db.example.com", port: 5432},
+			nil,
+			ErrInvalidHost,
+		},
+		{
+			"host with port",
+			args{name: "example", host: "db.example.com:5432", port: 5432},
+			nil,
+			ErrInvalidHost,
+		},
+		{
+			"zero port",
+			args{name: "example", host: "db.example.com", port: 0},
+			nil,
+			ErrInvalidPort,
+		},
+		{
+			"port out of range",
+			args{name: "example", host: "db.example.com", port: 65536},
+			nil,
+			ErrInvalidPort,
+		},
+		{
+			"hostname",
+			args{name: "example", host: "DB.example.com", port: 5432},
+			&Check{pingdom.TCPCheck{Name: "example", Hostname: "DB.example.com", Port: 5432, Resolution: 5}},
+			nil,
+		},
+		{
+			"ipv4 address",
+			args{name: "example", host: "192.0.2.1", port: 25},
+			&Check{pingdom.TCPCheck{Name: "example", Hostname: "192.0.2.1", Port: 25, Resolution: 5}},
+			nil,
+		},
+		{
+			"ipv6 address",
+			args{name: "example", host: "2001:db8::1", port: 25},
+			&Check{pingdom.TCPCheck{Name: "example", Hostname: "2001:db8::1", Port: 25, Resolution: 5}},
+			nil,
+		},
+		{
+			"with options",
+			args{name: "example", host: "smtp.example.com", port: 25, opts: []checkutil.Option{
+				checkutil.Resolution(1),
+				checkutil.NotifyWhenBackup(true),
+				checkutil.Tags([]string{"SMTP", "smtp"}),
+			}},
+			&Check{pingdom.TCPCheck{
+				Name:             "example",
+				Hostname:         "smtp.example.com",
+				Port:             25,
+				Resolution:       1,
+				NotifyWhenBackup: true,
+				Tags:             "smtp",
+			}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := SimpleTCPCheck(tt.args.name, tt.args.host, tt.args.port, tt.args.opts...)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.c, c)
+		})
+	}
+}
+
+func TestCheck_PutParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		check  pingdom.TCPCheck
+		params map[string]string
+	}{
+		{
+			"strings cleared",
+			pingdom.TCPCheck{Name: "example", Hostname: "db.example.com", Port: 5432},
+			map[string]string{"stringtosend": "", "stringtoexpect": ""},
+		},
+		{
+			"strings set",
+			pingdom.TCPCheck{Name: "example", Hostname: "db.example.com", Port: 5432, StringToSend: "PING", StringToExpect: "PONG"},
+			map[string]string{"stringtosend": "PING", "stringtoexpect": "PONG"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := (&Check{tt.check}).PutParams()
+			for key, value := range tt.params {
+				v, ok := params[key]
+				assert.True(t, ok, key)
+				assert.Equal(t, value, v, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+)
+
+// NotFoundError is returned when no pingdom team with the given name exists
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("could not find pingdom team %q", e.Name)
+}
+
+// IDsByName resolves team names to their pingdom ids
+func IDsByName(service Service, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	teams, err := service.List()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]int, len(teams))
+	for _, t := range teams {
+		id, err := strconv.Atoi(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		byName[t.Name] = id
+	}
+
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, &NotFoundError{Name: name}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ResolveIDs returns the given ids followed by the ids of the teams with the given names.
+// Every id is only returned once, so teams referenced twice do not show up as changes of the recipients.
+func ResolveIDs(service Service, ids []int, names []string) ([]int, error) {
+	resolved, err := IDsByName(service, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkutil.UniqueIDs(ids, resolved), nil
+}
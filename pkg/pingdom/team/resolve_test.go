@@ -0,0 +1,154 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	teams []pingdom.TeamResponse
+}
+
+func (s *fakeService) List() ([]pingdom.TeamResponse, error) {
+	return s.teams, nil
+}
+
+func (s *fakeService) Read(id int) (*pingdom.TeamResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Create(team pingdom.Team) (*pingdom.TeamResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Update(id int, team pingdom.Team) (*pingdom.TeamResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Delete(id int) (*pingdom.TeamDeleteResponse, error) {
+	return nil, nil
+}
+
+func TestIDsByName(t *testing.T) {
+	service := &fakeService{teams: []pingdom.TeamResponse{
+		{ID: "1", Name: "ops"},
+		{ID: "2", Name: "dev"},
+	}}
+
+	tests := []struct {
+		name  string
+		names []string
+		ids   []int
+		err   error
+	}{
+		{
+			"no names",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"single name",
+			[]string{"dev"},
+			[]int{2},
+			nil,
+		},
+		{
+			"multiple names",
+			[]string{"dev", "ops"},
+			[]int{2, 1},
+			nil,
+		},
+		{
+			"unknown name",
+			[]string{"ops", "qa"},
+			nil,
+			&NotFoundError{Name: "qa"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := IDsByName(service, tt.names)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+}
+
+func TestResolveIDs(t *testing.T) {
+	service := &fakeService{teams: []pingdom.TeamResponse{
+		{ID: "1", Name: "ops"},
+		{ID: "2", Name: "dev"},
+	}}
+
+	tests := []struct {
+		name  string
+		ids   []int
+		names []string
+		want  []int
+		err   error
+	}{
+		{
+			"no references",
+			nil,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"ids and names",
+			[]int{3},
+			[]string{"dev"},
+			[]int{3, 2},
+			nil,
+		},
+		{
+			"duplicate ids",
+			[]int{1, 1},
+			nil,
+			[]int{1},
+			nil,
+		},
+		{
+			"id and name of the same team",
+			[]int{1},
+			[]string{"ops"},
+			[]int{1},
+			nil,
+		},
+		{
+			"unknown name",
+			[]int{1},
+			[]string{"unknown"},
+			nil,
+			&NotFoundError{Name: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := ResolveIDs(service, tt.ids, tt.names)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
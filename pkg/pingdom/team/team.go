@@ -0,0 +1,100 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var (
+	ErrEmptyName = errors.New("the name should not be empty string")
+)
+
+// Team is a pingdom team. Unlike pingdom.TeamData it always sends its members,
+// so the last member can be removed from a team.
+type Team struct {
+	pingdom.TeamData
+}
+
+// PutParams returns the parameters of pingdom.TeamData including empty members
+func (t *Team) PutParams() map[string]string {
+	params := t.TeamData.PutParams()
+	params["userids"] = t.UserIds
+	return params
+}
+
+// SimpleTeam returns a team with the given name and members
+func SimpleTeam(name string, userIDs []int) (*Team, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	team := &Team{pingdom.TeamData{
+		Name:    name,
+		UserIds: joinIDs(userIDs),
+	}}
+
+	err := team.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Diff compares the desired team with the team read from pingdom and returns the names of all fields which differ
+func Diff(desired *Team, live *pingdom.TeamResponse) []string {
+	var diff []string
+
+	if desired.Name != live.Name {
+		diff = append(diff, "name")
+	}
+
+	ids := make([]int, 0, len(live.Users))
+	for _, u := range live.Users {
+		if id, err := strconv.Atoi(u.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	if desired.UserIds != joinIDs(ids) {
+		diff = append(diff, "userIds")
+	}
+
+	return diff
+}
+
+// joinIDs returns the sorted ids separated by commas. Duplicate ids are only included once.
+func joinIDs(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+
+	s := make([]string, 0, len(sorted))
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		s = append(s, strconv.Itoa(id))
+	}
+
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		teamName string
+		userIDs  []int
+		team     *Team
+		err      error
+	}{
+		{
+			"empty name",
+			"",
+			[]int{1},
+			nil,
+			ErrEmptyName,
+		},
+		{
+			"no members",
+			"ops",
+			nil,
+			&Team{pingdom.TeamData{Name: "ops"}},
+			nil,
+		},
+		{
+			"members are sorted",
+			"ops",
+			[]int{3, 1, 2},
+			&Team{pingdom.TeamData{Name: "ops", UserIds: "1,2,3"}},
+			nil,
+		},
+		{
+			"duplicate members",
+			"ops",
+			[]int{1, 2, 1},
+			&Team{pingdom.TeamData{Name: "ops", UserIds: "1,2"}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, err := SimpleTeam(tt.teamName, tt.userIDs)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.team, team)
+		})
+	}
+}
+
+func TestTeamPutParams(t *testing.T) {
+	team := &Team{pingdom.TeamData{Name: "ops"}}
+	assert.Equal(t, map[string]string{"name": "ops", "userids": ""}, team.PutParams())
+	assert.Equal(t, map[string]string{"name": "ops"}, team.PostParams())
+}
+
+func TestDiff(t *testing.T) {
+	desired := &Team{pingdom.TeamData{Name: "ops", UserIds: "1,2"}}
+
+	tests := []struct {
+		name string
+		live *pingdom.TeamResponse
+		diff []string
+	}{
+		{
+			"no changes",
+			&pingdom.TeamResponse{ID: "5", Name: "ops", Users: []pingdom.TeamUserResponse{{ID: "2"}, {ID: "1"}}},
+			nil,
+		},
+		{
+			"renamed",
+			&pingdom.TeamResponse{ID: "5", Name: "dev", Users: []pingdom.TeamUserResponse{{ID: "1"}, {ID: "2"}}},
+			[]string{"name"},
+		},
+		{
+			"member added",
+			&pingdom.TeamResponse{ID: "5", Name: "ops", Users: []pingdom.TeamUserResponse{{ID: "1"}, {ID: "2"}, {ID: "3"}}},
+			[]string{"userIds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, Diff(desired, tt.live))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Severity levels of contact targets
+const (
+	SeverityHigh = "HIGH"
+	SeverityLow  = "LOW"
+)
+
+var (
+	ErrEmptyName        = errors.New("the name of the user must not be empty")
+	ErrEmptyEmail       = errors.New("the address of an email target must not be empty")
+	ErrEmptyNumber      = errors.New("the number of a sms target must not be empty")
+	ErrEmptyCountryCode = errors.New("the country code of a sms target must not be empty")
+	ErrInvalidSeverity  = errors.New("the severity of a contact target must be either high or low")
+	ErrDuplicateContact = errors.New("contact targets must be unique")
+)
+
+// SimpleUser returns a pingdom user with the given name
+func SimpleUser(name string) (*pingdom.User, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	return &pingdom.User{Username: name}, nil
+}
+
+// EmailContact returns an email contact target. An empty severity defaults to high.
+func EmailContact(address string, severity string) (pingdom.Contact, error) {
+	if address == "" {
+		return pingdom.Contact{}, ErrEmptyEmail
+	}
+
+	severity, err := severityLevel(severity)
+	if err != nil {
+		return pingdom.Contact{}, err
+	}
+
+	return pingdom.Contact{Email: address, Severity: severity}, nil
+}
+
+// SMSContact returns a sms contact target. An empty severity defaults to high
+// and an empty provider lets pingdom choose the provider.
+func SMSContact(countryCode string, number string, provider string, severity string) (pingdom.Contact, error) {
+	countryCode = strings.TrimPrefix(strings.TrimSpace(countryCode), "+")
+	if countryCode == "" {
+		return pingdom.Contact{}, ErrEmptyCountryCode
+	}
+
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return pingdom.Contact{}, ErrEmptyNumber
+	}
+
+	severity, err := severityLevel(severity)
+	if err != nil {
+		return pingdom.Contact{}, err
+	}
+
+	return pingdom.Contact{
+		CountryCode: countryCode,
+		Number:      number,
+		Provider:    provider,
+		Severity:    severity,
+	}, nil
+}
+
+func severityLevel(severity string) (string, error) {
+	switch strings.ToUpper(severity) {
+	case "", SeverityHigh:
+		return SeverityHigh, nil
+	case SeverityLow:
+		return SeverityLow, nil
+	}
+
+	return "", ErrInvalidSeverity
+}
+
+// ByID returns the pingdom user with the given id or nil if it does not exist
+func ByID(service Service, id int) (*pingdom.UsersResponse, error) {
+	users, err := service.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if users[i].Id == id {
+			return &users[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ByName returns the first pingdom user with the given name or nil if there is none
+func ByName(service Service, name string) (*pingdom.UsersResponse, error) {
+	users, err := service.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if users[i].Username == name {
+			return &users[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Diff returns the fields of the live user which differ from the desired user
+func Diff(desired *pingdom.User, live *pingdom.UsersResponse) []string {
+	var diff []string
+
+	if desired.Username != live.Username {
+		diff = append(diff, "name")
+	}
+
+	return diff
+}
+
+// ContactUpdate is a change of an existing contact target
+type ContactUpdate struct {
+	ID      int
+	Contact pingdom.Contact
+}
+
+// ContactChanges are the changes needed to turn the contact targets of a live user into the desired ones
+type ContactChanges struct {
+	Create []pingdom.Contact
+	Update []ContactUpdate
+	Delete []int
+}
+
+// Empty returns true if there are no changes
+func (c ContactChanges) Empty() bool {
+	return len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0
+}
+
+// PlanContacts compares the desired contact targets with the targets of the live user.
+// Email targets are identified by their address and sms targets by their phone number,
+// so only changes of the severity or the provider are updated in place.
+func PlanContacts(desired []pingdom.Contact, live *pingdom.UsersResponse) (ContactChanges, error) {
+	var changes ContactChanges
+
+	type target struct {
+		id       int
+		severity string
+		provider string
+	}
+
+	existing := map[string]target{}
+	if live != nil {
+		for _, e := range live.Email {
+			existing[emailKey(e.Address)] = target{id: e.Id, severity: e.Severity}
+		}
+		for _, s := range live.Sms {
+			existing[smsKey(s.CountryCode, s.Number)] = target{id: s.Id, severity: s.Severity, provider: s.Provider}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, c := range desired {
+		key := contactKey(c)
+		if seen[key] {
+			return ContactChanges{}, ErrDuplicateContact
+		}
+		seen[key] = true
+
+		t, ok := existing[key]
+		if !ok {
+			changes.Create = append(changes.Create, c)
+			continue
+		}
+
+		if !strings.EqualFold(t.severity, c.Severity) || (c.Provider != "" && !strings.EqualFold(t.provider, c.Provider)) {
+			changes.Update = append(changes.Update, ContactUpdate{ID: t.id, Contact: c})
+		}
+	}
+
+	if live != nil {
+		for _, e := range live.Email {
+			if !seen[emailKey(e.Address)] {
+				changes.Delete = append(changes.Delete, e.Id)
+			}
+		}
+		for _, s := range live.Sms {
+			if !seen[smsKey(s.CountryCode, s.Number)] {
+				changes.Delete = append(changes.Delete, s.Id)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func contactKey(c pingdom.Contact) string {
+	if c.Email != "" {
+		return emailKey(c.Email)
+	}
+
+	return smsKey(c.CountryCode, c.Number)
+}
+
+func emailKey(address string) string {
+	return "email:" + strings.ToLower(address)
+}
+
+func smsKey(countryCode string, number string) string {
+	return "sms:" + strings.TrimPrefix(countryCode, "+") + strings.Replace(number, " ", "", -1)
+}
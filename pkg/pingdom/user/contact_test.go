@@ -0,0 +1,233 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailContact(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		severity string
+		contact  pingdom.Contact
+		err      error
+	}{
+		{
+			"empty address",
+			"",
+			"high",
+			pingdom.Contact{},
+			ErrEmptyEmail,
+		},
+		{
+			"default severity",
+			"ops@example.com",
+			"",
+			pingdom.Contact{Email: "ops@example.com", Severity: SeverityHigh},
+			nil,
+		},
+		{
+			"low severity",
+			"ops@example.com",
+			"low",
+			pingdom.Contact{Email: "ops@example.com", Severity: SeverityLow},
+			nil,
+		},
+		{
+			"invalid severity",
+			"ops@example.com",
+			"critical",
+			pingdom.Contact{},
+			ErrInvalidSeverity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact, err := EmailContact(tt.address, tt.severity)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.contact, contact)
+		})
+	}
+}
+
+func TestSMSContact(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		number      string
+		provider    string
+		contact     pingdom.Contact
+		err         error
+	}{
+		{
+			"empty country code",
+			"",
+			"1701234567",
+			"",
+			pingdom.Contact{},
+			ErrEmptyCountryCode,
+		},
+		{
+			"empty number",
+			"49",
+			" ",
+			"",
+			pingdom.Contact{},
+			ErrEmptyNumber,
+		},
+		{
+			"number with whitespace",
+			"+49",
+			"1701234567\n",
+			"nexmo",
+			pingdom.Contact{CountryCode: "49", Number: "1701234567", Provider: "nexmo", Severity: SeverityHigh},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact, err := SMSContact(tt.countryCode, tt.number, tt.provider, "")
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.contact, contact)
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	service := &fakeService{users: []pingdom.UsersResponse{
+		{Id: 1, Username: "alice"},
+		{Id: 2, Username: "bob"},
+		{Id: 3, Username: "bob"},
+	}}
+
+	tests := []struct {
+		name string
+		user string
+		id   int
+	}{
+		{"existing user", "alice", 1},
+		{"duplicate name", "bob", 2},
+		{"unknown user", "carol", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ByName(service, tt.user)
+			assert.NoError(t, err)
+			if tt.id == 0 {
+				assert.Nil(t, u)
+				return
+			}
+			assert.Equal(t, tt.id, u.Id)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	desired := &pingdom.User{Username: "alice"}
+
+	assert.Empty(t, Diff(desired, &pingdom.UsersResponse{Id: 1, Username: "alice"}))
+	assert.Equal(t, []string{"name"}, Diff(desired, &pingdom.UsersResponse{Id: 1, Username: "bob"}))
+}
+
+func TestPlanContacts(t *testing.T) {
+	live := &pingdom.UsersResponse{
+		Id:       1,
+		Username: "alice",
+		Email: []pingdom.UserEmailResponse{
+			{Id: 10, Address: "Alice@example.com", Severity: "HIGH"},
+			{Id: 11, Address: "old@example.com", Severity: "HIGH"},
+		},
+		Sms: []pingdom.UserSmsResponse{
+			{Id: 20, CountryCode: "49", Number: "1701234567", Provider: "nexmo", Severity: "HIGH"},
+		},
+	}
+
+	email := pingdom.Contact{Email: "alice@example.com", Severity: SeverityHigh}
+	sms := pingdom.Contact{CountryCode: "49", Number: "1701234567", Severity: SeverityHigh}
+
+	tests := []struct {
+		name    string
+		desired []pingdom.Contact
+		live    *pingdom.UsersResponse
+		changes ContactChanges
+		err     error
+	}{
+		{
+			"new user",
+			[]pingdom.Contact{email, sms},
+			nil,
+			ContactChanges{Create: []pingdom.Contact{email, sms}},
+			nil,
+		},
+		{
+			"removed target",
+			[]pingdom.Contact{email, sms},
+			live,
+			ContactChanges{Delete: []int{11}},
+			nil,
+		},
+		{
+			"changed severity",
+			[]pingdom.Contact{{Email: "alice@example.com", Severity: SeverityLow}, sms},
+			live,
+			ContactChanges{
+				Update: []ContactUpdate{{ID: 10, Contact: pingdom.Contact{Email: "alice@example.com", Severity: SeverityLow}}},
+				Delete: []int{11},
+			},
+			nil,
+		},
+		{
+			"changed provider",
+			[]pingdom.Contact{email, {CountryCode: "49", Number: "1701234567", Provider: "bulksms", Severity: SeverityHigh}},
+			live,
+			ContactChanges{
+				Update: []ContactUpdate{{ID: 20, Contact: pingdom.Contact{CountryCode: "49", Number: "1701234567", Provider: "bulksms", Severity: SeverityHigh}}},
+				Delete: []int{11},
+			},
+			nil,
+		},
+		{
+			"changed number",
+			[]pingdom.Contact{email, {CountryCode: "49", Number: "1709999999", Severity: SeverityHigh}},
+			live,
+			ContactChanges{
+				Create: []pingdom.Contact{{CountryCode: "49", Number: "1709999999", Severity: SeverityHigh}},
+				Delete: []int{11, 20},
+			},
+			nil,
+		},
+		{
+			"duplicate target",
+			[]pingdom.Contact{email, email},
+			live,
+			ContactChanges{},
+			ErrDuplicateContact,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := PlanContacts(tt.desired, tt.live)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/fbsb/pingdom-operator/pkg/pingdom/checkutil"
+)
+
+// NotFoundError is returned when no pingdom user with the given name exists
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("could not find pingdom user %q", e.Name)
+}
+
+// IDsByName resolves user names to their pingdom ids
+func IDsByName(service Service, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	users, err := service.List()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]int, len(users))
+	for _, u := range users {
+		byName[u.Username] = u.Id
+	}
+
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, &NotFoundError{Name: name}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ResolveIDs returns the given ids followed by the ids of the users with the given names.
+// Every id is only returned once, so users referenced twice do not show up as changes of the recipients.
+func ResolveIDs(service Service, ids []int, names []string) ([]int, error) {
+	resolved, err := IDsByName(service, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkutil.UniqueIDs(ids, resolved), nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	users []pingdom.UsersResponse
+}
+
+func (s *fakeService) List() ([]pingdom.UsersResponse, error) {
+	return s.users, nil
+}
+
+func (s *fakeService) Create(user pingdom.UserApi) (*pingdom.UsersResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Update(id int, user pingdom.UserApi) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) Delete(id int) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) CreateContact(userID int, contact pingdom.Contact) (*pingdom.CreateUserContactResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) UpdateContact(userID int, contactID int, contact pingdom.Contact) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func (s *fakeService) DeleteContact(userID int, contactID int) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func TestIDsByName(t *testing.T) {
+	service := &fakeService{users: []pingdom.UsersResponse{
+		{Id: 1, Username: "alice"},
+		{Id: 2, Username: "bob"},
+	}}
+
+	tests := []struct {
+		name  string
+		names []string
+		ids   []int
+		err   error
+	}{
+		{
+			"no names",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"single name",
+			[]string{"bob"},
+			[]int{2},
+			nil,
+		},
+		{
+			"multiple names",
+			[]string{"bob", "alice"},
+			[]int{2, 1},
+			nil,
+		},
+		{
+			"unknown name",
+			[]string{"alice", "carol"},
+			nil,
+			&NotFoundError{Name: "carol"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := IDsByName(service, tt.names)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+}
+
+func TestResolveIDs(t *testing.T) {
+	service := &fakeService{users: []pingdom.UsersResponse{
+		{Id: 1, Username: "alice"},
+		{Id: 2, Username: "bob"},
+	}}
+
+	tests := []struct {
+		name  string
+		ids   []int
+		names []string
+		want  []int
+		err   error
+	}{
+		{
+			"no references",
+			nil,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"ids and names",
+			[]int{3},
+			[]string{"bob"},
+			[]int{3, 2},
+			nil,
+		},
+		{
+			"duplicate ids",
+			[]int{1, 1},
+			nil,
+			[]int{1},
+			nil,
+		},
+		{
+			"id and name of the same user",
+			[]int{1},
+			[]string{"alice"},
+			[]int{1},
+			nil,
+		},
+		{
+			"unknown name",
+			[]int{1},
+			[]string{"unknown"},
+			nil,
+			&NotFoundError{Name: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := ResolveIDs(service, tt.ids, tt.names)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
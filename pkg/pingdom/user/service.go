@@ -0,0 +1,57 @@
+/*
+Copyright 2019 Fabian Sabau <fabian.sabau@gmail.com>.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"errors"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var (
+	ErrAlreadyInitialized = errors.New("the user service has already been initialized")
+	ErrNotInitialized     = errors.New("the user service has not been initialized")
+)
+
+type Service interface {
+	List() ([]pingdom.UsersResponse, error)
+	Create(user pingdom.UserApi) (*pingdom.UsersResponse, error)
+	Update(id int, user pingdom.UserApi) (*pingdom.PingdomResponse, error)
+	Delete(id int) (*pingdom.PingdomResponse, error)
+	CreateContact(userID int, contact pingdom.Contact) (*pingdom.CreateUserContactResponse, error)
+	UpdateContact(userID int, contactID int, contact pingdom.Contact) (*pingdom.PingdomResponse, error)
+	DeleteContact(userID int, contactID int) (*pingdom.PingdomResponse, error)
+}
+
+var instance Service
+
+func InitService(client *pingdom.Client) error {
+	if instance == nil {
+		instance = client.Users
+		return nil
+	}
+
+	return ErrAlreadyInitialized
+}
+
+func ServiceInstance() (Service, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	return nil, ErrNotInitialized
+}